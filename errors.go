@@ -0,0 +1,342 @@
+package smp
+
+import (
+	"fmt"
+)
+
+// Legacy (SMP v1) return codes, which are shared between all groups.
+//
+// https://docs.zephyrproject.org/latest/services/device_mgmt/smp_protocol.html#minimal-response-smp-data
+const (
+	RcOK           = 0
+	RcUnknown      = 1
+	RcNoMemory     = 2
+	RcInvalid      = 3
+	RcTimeout      = 4
+	RcNoEntry      = 5
+	RcBadState     = 6
+	RcMsgSize      = 7
+	RcNotSupported = 8
+	RcCorrupt      = 9
+	RcBusy         = 10
+	RcAccessDenied = 11
+	RcTooOld       = 12
+	RcTooNew       = 13
+	RcPerUser      = 256
+)
+
+// Return codes of OS group.
+const (
+	RcOSUnknown                    = 1
+	RcOSInvalidFormat              = 2
+	RcOSQueryYieldsNoAnswer        = 3
+	RcOSRTCNotSet                  = 4
+	RcOSRTCCommandFailed           = 5
+	RcOSQueryResponseValueNotValid = 6
+)
+
+// Return codes of Image group.
+const (
+	RcImageUnknown                   = 1
+	RcImageFlashConfigQueryFail      = 2
+	RcImageNoImage                   = 3
+	RcImageNoTLVs                    = 4
+	RcImageInvalidTLV                = 5
+	RcImageTLVMultipleHashesFound    = 6
+	RcImageTLVInvalidSize            = 7
+	RcImageHashNotFound              = 8
+	RcImageNoFreeSlot                = 9
+	RcImageFlashOpenFailed           = 10
+	RcImageFlashReadFailed           = 11
+	RcImageFlashWriteFailed          = 12
+	RcImageFlashEraseFailed          = 13
+	RcImageInvalidSlot               = 14
+	RcImageNoFreeMemory              = 15
+	RcImageFlashContextAlreadySet    = 16
+	RcImageFlashContextNotSet        = 17
+	RcImageFlashAreaDeviceNull       = 18
+	RcImageInvalidPageOffset         = 19
+	RcImageInvalidOffset             = 20
+	RcImageInvalidLength             = 21
+	RcImageInvalidImageHeader        = 22
+	RcImageInvalidImageHeaderMagic   = 23
+	RcImageInvalidHash               = 24
+	RcImageInvalidFlashAddress       = 25
+	RcImageVersionGetFailed          = 26
+	RcImageCurrentVersionIsNewer     = 27
+	RcImageAlreadyPending            = 28
+	RcImageInvalidImageVectorTable   = 29
+	RcImageInvalidImageTooLarge      = 30
+	RcImageInvalidImageDataOverrun   = 31
+	RcImageConfirmationDenied        = 32
+	RcImageSettingTestToActiveDenied = 33
+	RcImageActiveSlotNotKnown        = 34
+)
+
+// Return codes of File System group.
+const (
+	RcFSUnknown                  = 1
+	RcFSFileInvalidName          = 2
+	RcFSFileNotFound             = 3
+	RcFSFileIsDirectory          = 4
+	RcFSFileOpenFailed           = 5
+	RcFSFileSeekFailed           = 6
+	RcFSFileReadFailed           = 7
+	RcFSFileTruncateFailed       = 8
+	RcFSFileDeleteFailed         = 9
+	RcFSFileWriteFailed          = 10
+	RcFSFileOffsetNotValid       = 11
+	RcFSFileOffsetLargerThanFile = 12
+	RcFSChecksumHashNotFound     = 13
+	RcFSMountPointNotFound       = 14
+	RcFSReadOnlyFilesystem       = 15
+	RcFSFileEmpty                = 16
+)
+
+// Return codes of Statistics group.
+const (
+	RcStatUnknown         = 1
+	RcStatInvalidGroup    = 2
+	RcStatInvalidStatName = 3
+	RcStatInvalidStatSize = 4
+	RcStatWalkAborted     = 5
+)
+
+// Return codes of Settings group.
+const (
+	RcSettingsUnknown            = 1
+	RcSettingsKeyTooLong         = 2
+	RcSettingsKeyNotFound        = 3
+	RcSettingsReadNotSupported   = 4
+	RcSettingsRootKeyNotFound    = 5
+	RcSettingsWriteNotSupported  = 6
+	RcSettingsDeleteNotSupported = 7
+	RcSettingsSaveFailed         = 8
+)
+
+// Return codes of Shell group.
+const (
+	RcShellUnknown        = 1
+	RcShellCommandTooLong = 2
+	RcShellEmptyCommand   = 3
+)
+
+// Return codes of Enumeration group.
+const (
+	RcEnumUnknown                    = 1
+	RcEnumTooManyGroupEntries        = 2
+	RcEnumInsufficientHeapForEntries = 3
+	RcEnumIndexTooLarge              = 4
+)
+
+// Return codes of Zephyr Basic group.
+const (
+	RcZephyrBasicUnknown              = 1
+	RcZephyrBasicFlashOpenFailed      = 2
+	RcZephyrBasicFlashConfigQueryFail = 3
+	RcZephyrBasicFlashEraseFailed     = 4
+)
+
+var legacyRcNames = map[int]string{
+	RcOK:           "ok",
+	RcUnknown:      "unknown error",
+	RcNoMemory:     "no memory",
+	RcInvalid:      "invalid value",
+	RcTimeout:      "timeout",
+	RcNoEntry:      "no such entry",
+	RcBadState:     "bad state",
+	RcMsgSize:      "response too large",
+	RcNotSupported: "command not supported",
+	RcCorrupt:      "corrupt",
+	RcBusy:         "busy",
+	RcAccessDenied: "access denied",
+	RcTooOld:       "protocol version too old",
+	RcTooNew:       "protocol version too new",
+	RcPerUser:      "user-defined error",
+}
+
+var groupRcNames = map[uint16]map[int]string{
+	SMPGroupOS: {
+		RcOSUnknown:                    "unknown error",
+		RcOSInvalidFormat:              "invalid format",
+		RcOSQueryYieldsNoAnswer:        "query yields no answer",
+		RcOSRTCNotSet:                  "rtc not set",
+		RcOSRTCCommandFailed:           "rtc command failed",
+		RcOSQueryResponseValueNotValid: "query response value not valid",
+	},
+	SMPGroupImage: {
+		RcImageUnknown:                   "unknown error",
+		RcImageFlashConfigQueryFail:      "flash config query failed",
+		RcImageNoImage:                   "no image",
+		RcImageNoTLVs:                    "no tlvs",
+		RcImageInvalidTLV:                "invalid tlv",
+		RcImageTLVMultipleHashesFound:    "multiple hashes found in tlv",
+		RcImageTLVInvalidSize:            "invalid tlv size",
+		RcImageHashNotFound:              "hash not found",
+		RcImageNoFreeSlot:                "no free slot",
+		RcImageFlashOpenFailed:           "flash open failed",
+		RcImageFlashReadFailed:           "flash read failed",
+		RcImageFlashWriteFailed:          "flash write failed",
+		RcImageFlashEraseFailed:          "flash erase failed",
+		RcImageInvalidSlot:               "invalid slot",
+		RcImageNoFreeMemory:              "no free memory",
+		RcImageFlashContextAlreadySet:    "flash context already set",
+		RcImageFlashContextNotSet:        "flash context not set",
+		RcImageFlashAreaDeviceNull:       "flash area device is null",
+		RcImageInvalidPageOffset:         "invalid page offset",
+		RcImageInvalidOffset:             "invalid offset",
+		RcImageInvalidLength:             "invalid length",
+		RcImageInvalidImageHeader:        "invalid image header",
+		RcImageInvalidImageHeaderMagic:   "invalid image header magic",
+		RcImageInvalidHash:               "invalid hash",
+		RcImageInvalidFlashAddress:       "invalid flash address",
+		RcImageVersionGetFailed:          "version get failed",
+		RcImageCurrentVersionIsNewer:     "current version is newer",
+		RcImageAlreadyPending:            "image already pending",
+		RcImageInvalidImageVectorTable:   "invalid image vector table",
+		RcImageInvalidImageTooLarge:      "image too large",
+		RcImageInvalidImageDataOverrun:   "image data overrun",
+		RcImageConfirmationDenied:        "image confirmation denied",
+		RcImageSettingTestToActiveDenied: "setting test to active slot denied",
+		RcImageActiveSlotNotKnown:        "active slot not known",
+	},
+	SMPGroupFS: {
+		RcFSUnknown:                  "unknown error",
+		RcFSFileInvalidName:          "invalid file name",
+		RcFSFileNotFound:             "file not found",
+		RcFSFileIsDirectory:          "file is directory",
+		RcFSFileOpenFailed:           "file open failed",
+		RcFSFileSeekFailed:           "file seek failed",
+		RcFSFileReadFailed:           "file read failed",
+		RcFSFileTruncateFailed:       "file truncate failed",
+		RcFSFileDeleteFailed:         "file delete failed",
+		RcFSFileWriteFailed:          "file write failed",
+		RcFSFileOffsetNotValid:       "file offset not valid",
+		RcFSFileOffsetLargerThanFile: "file offset larger than file",
+		RcFSChecksumHashNotFound:     "checksum or hash not found",
+		RcFSMountPointNotFound:       "mount point not found",
+		RcFSReadOnlyFilesystem:       "read-only filesystem",
+		RcFSFileEmpty:                "file empty",
+	},
+	SMPGroupStat: {
+		RcStatUnknown:         "unknown error",
+		RcStatInvalidGroup:    "invalid group",
+		RcStatInvalidStatName: "invalid stat name",
+		RcStatInvalidStatSize: "invalid stat size",
+		RcStatWalkAborted:     "walk aborted",
+	},
+	SMPGroupSettings: {
+		RcSettingsUnknown:            "unknown error",
+		RcSettingsKeyTooLong:         "key too long",
+		RcSettingsKeyNotFound:        "key not found",
+		RcSettingsReadNotSupported:   "read not supported",
+		RcSettingsRootKeyNotFound:    "root key not found",
+		RcSettingsWriteNotSupported:  "write not supported",
+		RcSettingsDeleteNotSupported: "delete not supported",
+		RcSettingsSaveFailed:         "save failed",
+	},
+	SMPGroupShell: {
+		RcShellUnknown:        "unknown error",
+		RcShellCommandTooLong: "command too long",
+		RcShellEmptyCommand:   "empty command",
+	},
+	SMPGroupEnumeration: {
+		RcEnumUnknown:                    "unknown error",
+		RcEnumTooManyGroupEntries:        "too many group entries",
+		RcEnumInsufficientHeapForEntries: "insufficient heap for entries",
+		RcEnumIndexTooLarge:              "index too large",
+	},
+	SMPGroupZephyrBasic: {
+		RcZephyrBasicUnknown:              "unknown error",
+		RcZephyrBasicFlashOpenFailed:      "flash open failed",
+		RcZephyrBasicFlashConfigQueryFail: "flash config query failed",
+		RcZephyrBasicFlashEraseFailed:     "flash erase failed",
+	},
+}
+
+// SMPError is returned by SMPClient methods when device responds with error.
+//
+// It can be retrieved with [errors.As], or compared with [errors.Is]:
+//
+//	errors.Is(err, &SMPError{Group: SMPGroupImage, Rc: RcImageNoFreeSlot})
+type SMPError struct {
+	// Group that reported the error. Not set if Legacy is true.
+	Group uint16
+	// Rc is group-specific return code,
+	// or one of legacy return codes (Rc*) shared by all groups if Legacy is true.
+	Rc int
+	// Legacy is true if device responded with top-level `rc` without group.
+	//
+	// It does not depend on SMP version of the response: SMP v1 devices
+	// report all errors this way, and SMP v2 devices - generic errors
+	// that are not group-specific, i.e. RcNotSupported.
+	Legacy bool
+
+	// rcName is the name of return code for user-defined groups.
+	rcName string
+}
+
+// responseStatus holds error fields that can be present in any response.
+type responseStatus struct {
+	// Rc is reported by SMP v1 devices for all errors,
+	// and by SMP v2 devices for generic errors that are not group-specific.
+	Rc *int `cbor:"rc,omitempty"`
+	// Err is reported only by SMP v2 devices.
+	Err *ErrorResponse `cbor:"err,omitempty"`
+}
+
+// smpError returns error reported in response, or nil on success.
+//
+// `version` is the version from response header. Legacy responses
+// can only contain `rc`, so `err` is not checked for them.
+func (s responseStatus) smpError(version uint8) *SMPError {
+	if s.Rc != nil && *s.Rc != RcOK {
+		return &SMPError{Rc: *s.Rc, Legacy: true}
+	}
+
+	if version != SMPVersionLegacy && s.Err != nil && s.Err.Rc != RcOK {
+		return &SMPError{Group: s.Err.Group, Rc: s.Err.Rc}
+	}
+
+	return nil
+}
+
+// Name returns human-readable name of the return code.
+func (e *SMPError) Name() string {
+	if e.Legacy {
+		if name, ok := legacyRcNames[e.Rc]; ok {
+			return name
+		}
+
+		return "unknown"
+	}
+
+	if e.rcName != "" {
+		return e.rcName
+	}
+
+	if name, ok := groupRcNames[e.Group][e.Rc]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// Error implements error.
+func (e *SMPError) Error() string {
+	if e.Legacy {
+		return fmt.Sprintf("smp error: rc=%d (%s)", e.Rc, e.Name())
+	}
+
+	return fmt.Sprintf("smp error: group=%d, rc=%d (%s)", e.Group, e.Rc, e.Name())
+}
+
+// Is reports whether target is SMPError with the same group and return code.
+func (e *SMPError) Is(target error) bool {
+	t, ok := target.(*SMPError)
+	if !ok {
+		return false
+	}
+
+	return e.Legacy == t.Legacy && e.Group == t.Group && e.Rc == t.Rc
+}
@@ -0,0 +1,120 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSMPError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err     *SMPError
+		wantMsg string
+	}{
+		{
+			err:     &SMPError{Group: SMPGroupImage, Rc: RcImageAlreadyPending},
+			wantMsg: "smp error: group=1, rc=28 (image already pending)",
+		},
+		{
+			err:     &SMPError{Group: SMPGroupFS, Rc: RcFSFileNotFound},
+			wantMsg: "smp error: group=8, rc=3 (file not found)",
+		},
+		{
+			err:     &SMPError{Rc: RcBadState, Legacy: true},
+			wantMsg: "smp error: rc=6 (bad state)",
+		},
+		{
+			err:     &SMPError{Group: SMPGroupUserDefined, Rc: 1},
+			wantMsg: "smp error: group=64, rc=1 (unknown)",
+		},
+	}
+
+	for _, tt := range tests {
+		if msg := tt.err.Error(); msg != tt.wantMsg {
+			t.Fatalf("wrong message: %q, want: %q", msg, tt.wantMsg)
+		}
+
+		wrapped := fmt.Errorf("command failed: %w", tt.err)
+
+		var smpErr *SMPError
+		if !errors.As(wrapped, &smpErr) || smpErr != tt.err {
+			t.Fatalf("error must be retrievable with errors.As")
+		}
+
+		if !errors.Is(wrapped, &SMPError{Group: tt.err.Group, Rc: tt.err.Rc, Legacy: tt.err.Legacy}) {
+			t.Fatalf("error must match with errors.Is")
+		}
+
+		if errors.Is(wrapped, &SMPError{Group: tt.err.Group, Rc: tt.err.Rc + 1, Legacy: tt.err.Legacy}) {
+			t.Fatalf("error must not match different rc")
+		}
+	}
+}
+
+func TestLegacyResponses(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	tests := []struct {
+		name    string
+		legacy  bool
+		resp    map[string]any
+		wantErr *SMPError
+	}{
+		{
+			name:   "legacy success",
+			legacy: true,
+			resp:   map[string]any{"rc": 0},
+		},
+		{
+			name:    "legacy error",
+			legacy:  true,
+			resp:    map[string]any{"rc": RcBadState},
+			wantErr: &SMPError{Rc: RcBadState, Legacy: true},
+		},
+		{
+			// Top-level rc is reported as legacy error regardless of frame version.
+			name:    "v2 top-level rc error",
+			resp:    map[string]any{"rc": RcNotSupported},
+			wantErr: &SMPError{Rc: RcNotSupported, Legacy: true},
+		},
+		{
+			name:    "v2 group error",
+			resp:    map[string]any{"err": map[string]any{"group": SMPGroupOS, "rc": RcOSUnknown}},
+			wantErr: &SMPError{Group: SMPGroupOS, Rc: RcOSUnknown},
+		},
+	}
+
+	for _, tt := range tests {
+		transport := newDefaultTestTransport()
+		transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+			wantVersion := uint8(SMPVersion2)
+			if tt.legacy {
+				wantVersion = SMPVersionLegacy
+			}
+
+			if frame.Header.Version != wantVersion {
+				t.Errorf("%s: wrong request version: %d", tt.name, frame.Header.Version)
+			}
+
+			// Response has the same version as request.
+			return newTestResponse(frame, tt.resp), nil
+		}
+
+		client := NewSMPClientWithConfig(transport, SMPClientConfig{Legacy: tt.legacy})
+
+		err := client.ResetDevice(ctx, false)
+		switch {
+		case tt.wantErr == nil && err != nil:
+			t.Fatalf("%s: unexpected error: %s", tt.name, err.Error())
+		case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+			t.Fatalf("%s: wrong error: %v, want: %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
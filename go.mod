@@ -3,11 +3,14 @@ module github.com/ffenix113/smp
 go 1.22.1
 
 require (
+	github.com/creack/pty v1.1.21
 	github.com/fxamacker/cbor/v2 v2.9.0
+	go.bug.st/serial v1.6.2
 	tinygo.org/x/bluetooth v0.13.0
 )
 
 require (
+	github.com/creack/goselect v0.1.2 // indirect
 	github.com/go-ole/go-ole v1.2.6 // indirect
 	github.com/godbus/dbus/v5 v5.1.0 // indirect
 	github.com/saltosystems/winrt-go v0.0.0-20240509164145-4f7860a3bd2b // indirect
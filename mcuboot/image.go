@@ -0,0 +1,363 @@
+// Package mcuboot parses MCUboot images, i.e. `zephyr.signed.bin`,
+// so they can be inspected and validated before upload.
+//
+// https://docs.mcuboot.com/design.html#image-format
+package mcuboot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+// Image header magic numbers.
+const (
+	ImageMagic   = 0x96f3b83d
+	ImageMagicV1 = 0x96f3b83c
+)
+
+// HeaderSize is the size of the image header structure.
+// Header area of the image (Header.HdrSize) may be larger than this.
+const HeaderSize = 32
+
+// Magic numbers of TLV areas.
+const (
+	TLVInfoMagic          = 0x6907
+	TLVProtectedInfoMagic = 0x6908
+)
+
+// tlvInfoSize is the size of TLV area header: magic and total size.
+const tlvInfoSize = 4
+
+// tlvHeaderSize is the size of single TLV header: type and length.
+const tlvHeaderSize = 4
+
+// Image header flags.
+const (
+	FlagPIC                = 0x00000001 // Position independent code, not supported
+	FlagEncryptedAES128    = 0x00000004 // Image is encrypted with AES128
+	FlagEncryptedAES256    = 0x00000008 // Image is encrypted with AES256
+	FlagNonBootable        = 0x00000010 // Split image app
+	FlagRAMLoad            = 0x00000020 // Image is executed from RAM
+	FlagROMFixed           = 0x00000100 // Image must be placed at the fixed address
+	FlagCompressedLZMA1    = 0x00000200 // Image is compressed with LZMA1
+	FlagCompressedLZMA2    = 0x00000400 // Image is compressed with LZMA2
+	FlagCompressedARMThumb = 0x00000800 // Compressed image uses ARM Thumb filter
+)
+
+// TLV types.
+const (
+	TLVKeyHash         = 0x01 // Hash of the public key used to sign the image
+	TLVPubKey          = 0x02 // Public key used to sign the image
+	TLVSHA256          = 0x10 // SHA256 of the image
+	TLVSHA384          = 0x11 // SHA384 of the image
+	TLVSHA512          = 0x12 // SHA512 of the image
+	TLVRSA2048PSS      = 0x20 // RSA2048 PSS signature
+	TLVECDSA224        = 0x21 // ECDSA P224 signature
+	TLVECDSASig        = 0x22 // ECDSA P256/P384 signature
+	TLVRSA3072PSS      = 0x23 // RSA3072 PSS signature
+	TLVED25519         = 0x24 // ED25519 signature
+	TLVSigPure         = 0x25 // Signature is over the image itself, not its hash
+	TLVEncRSA2048      = 0x30 // Key encrypted with RSA-OAEP-2048
+	TLVEncKW           = 0x31 // Key encrypted with AES-KW
+	TLVEncEC256        = 0x32 // Key encrypted with ECIES-EC256
+	TLVEncX25519       = 0x33 // Key encrypted with ECIES-X25519
+	TLVDependency      = 0x40 // Image depends on other image
+	TLVSecurityCounter = 0x50 // Security counter of the image
+	TLVBootRecord      = 0x60 // Measured boot record
+)
+
+// dependencySize is the size of TLVDependency data.
+const dependencySize = 12
+
+// Version is the version of the image.
+type Version struct {
+	Major    uint8
+	Minor    uint8
+	Revision uint16
+	BuildNum uint32
+}
+
+// String returns version in `major.minor.revision+build` form.
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d+%d", v.Major, v.Minor, v.Revision, v.BuildNum)
+}
+
+// Header is the header of MCUboot image.
+type Header struct {
+	Magic    uint32
+	LoadAddr uint32
+	// HdrSize is the size of header area, image starts right after it.
+	HdrSize uint16
+	// ProtectTLVSize is the size of protected TLV area, including its info header.
+	// Protected TLVs are covered by image hash and signature.
+	ProtectTLVSize uint16
+	// ImgSize is the size of image itself, without header and TLVs.
+	ImgSize uint32
+	Flags   uint32
+	Version Version
+}
+
+// HasFlag returns true if all bits of `flag` are set in header flags.
+func (h Header) HasFlag(flag uint32) bool {
+	return h.Flags&flag == flag
+}
+
+// TLV is a single type-length-value entry of TLV area.
+type TLV struct {
+	Type uint16
+	Data []byte
+	// Protected is true if TLV is in protected area,
+	// which is covered by image hash and signature.
+	Protected bool
+}
+
+// Dependency describes dependency of the image on other image.
+type Dependency struct {
+	ImageID    uint8
+	MinVersion Version
+}
+
+// Image is parsed MCUboot image.
+type Image struct {
+	Header Header
+	TLVs   []TLV
+
+	// data is the whole image, used to verify the hash.
+	data []byte
+}
+
+// Parse parses MCUboot image: header and TLV areas.
+//
+// Image data is referenced by returned image, so it must not be modified.
+func Parse(data []byte) (Image, error) {
+	header, err := parseHeader(data)
+	if err != nil {
+		return Image{}, err
+	}
+
+	img := Image{Header: header, data: data}
+
+	off := int(header.HdrSize) + int(header.ImgSize)
+	if off > len(data) {
+		return Image{}, fmt.Errorf("image size %d exceeds data size %d", off, len(data))
+	}
+
+	if header.ProtectTLVSize != 0 {
+		tlvs, err := parseTLVArea(data[off:], TLVProtectedInfoMagic, true)
+		if err != nil {
+			return Image{}, fmt.Errorf("parse protected tlvs: %w", err)
+		}
+
+		if size := tlvAreaSize(data[off:]); size != int(header.ProtectTLVSize) {
+			return Image{}, fmt.Errorf("protected tlv area size %d does not match header %d", size, header.ProtectTLVSize)
+		}
+
+		img.TLVs = append(img.TLVs, tlvs...)
+		off += int(header.ProtectTLVSize)
+	}
+
+	tlvs, err := parseTLVArea(data[off:], TLVInfoMagic, false)
+	if err != nil {
+		return Image{}, fmt.Errorf("parse tlvs: %w", err)
+	}
+
+	img.TLVs = append(img.TLVs, tlvs...)
+
+	return img, nil
+}
+
+func parseHeader(data []byte) (Header, error) {
+	if len(data) < HeaderSize {
+		return Header{}, fmt.Errorf("image too small: %d bytes, header requires %d", len(data), HeaderSize)
+	}
+
+	le := binary.LittleEndian
+
+	header := Header{
+		Magic:          le.Uint32(data[0:]),
+		LoadAddr:       le.Uint32(data[4:]),
+		HdrSize:        le.Uint16(data[8:]),
+		ProtectTLVSize: le.Uint16(data[10:]),
+		ImgSize:        le.Uint32(data[12:]),
+		Flags:          le.Uint32(data[16:]),
+		Version:        parseVersion(data[20:]),
+	}
+
+	if header.Magic != ImageMagic && header.Magic != ImageMagicV1 {
+		return Header{}, fmt.Errorf("invalid image magic: %#08x", header.Magic)
+	}
+
+	if header.HdrSize < HeaderSize {
+		return Header{}, fmt.Errorf("header size %d is smaller than %d", header.HdrSize, HeaderSize)
+	}
+
+	return header, nil
+}
+
+func parseVersion(data []byte) Version {
+	return Version{
+		Major:    data[0],
+		Minor:    data[1],
+		Revision: binary.LittleEndian.Uint16(data[2:]),
+		BuildNum: binary.LittleEndian.Uint32(data[4:]),
+	}
+}
+
+// tlvAreaSize returns total size of TLV area from its info header.
+func tlvAreaSize(data []byte) int {
+	return int(binary.LittleEndian.Uint16(data[2:]))
+}
+
+// parseTLVArea parses TLV area at the start of `data`,
+// which must start with info header with `magic`.
+func parseTLVArea(data []byte, magic uint16, protected bool) ([]TLV, error) {
+	if len(data) < tlvInfoSize {
+		return nil, errors.New("tlv area is missing")
+	}
+
+	if got := binary.LittleEndian.Uint16(data); got != magic {
+		return nil, fmt.Errorf("invalid tlv area magic: %#04x, want %#04x", got, magic)
+	}
+
+	size := tlvAreaSize(data)
+	if size < tlvInfoSize || size > len(data) {
+		return nil, fmt.Errorf("invalid tlv area size %d, %d bytes available", size, len(data))
+	}
+
+	var tlvs []TLV
+	for off := tlvInfoSize; off < size; {
+		if off+tlvHeaderSize > size {
+			return nil, fmt.Errorf("truncated tlv header at offset %d", off)
+		}
+
+		tlvType := binary.LittleEndian.Uint16(data[off:])
+		tlvLen := int(binary.LittleEndian.Uint16(data[off+2:]))
+		off += tlvHeaderSize
+
+		if off+tlvLen > size {
+			return nil, fmt.Errorf("tlv %#02x of %d bytes exceeds tlv area", tlvType, tlvLen)
+		}
+
+		tlvs = append(tlvs, TLV{Type: tlvType, Data: data[off : off+tlvLen], Protected: protected})
+		off += tlvLen
+	}
+
+	return tlvs, nil
+}
+
+// Find returns data of the first TLV of type `tlvType`.
+func (i Image) Find(tlvType uint16) ([]byte, bool) {
+	for _, tlv := range i.TLVs {
+		if tlv.Type == tlvType {
+			return tlv.Data, true
+		}
+	}
+
+	return nil, false
+}
+
+// Hash returns type and value of image hash.
+//
+// Value of SHA256 hash is the same as image hash
+// reported by the device in image state.
+func (i Image) Hash() (uint16, []byte, bool) {
+	for _, tlvType := range []uint16{TLVSHA256, TLVSHA384, TLVSHA512} {
+		if value, ok := i.Find(tlvType); ok {
+			return tlvType, value, true
+		}
+	}
+
+	return 0, nil, false
+}
+
+// KeyHash returns hash of the public key the image was signed with.
+func (i Image) KeyHash() ([]byte, bool) {
+	return i.Find(TLVKeyHash)
+}
+
+// Signatures returns signature TLVs of the image.
+func (i Image) Signatures() []TLV {
+	var signatures []TLV
+	for _, tlv := range i.TLVs {
+		switch tlv.Type {
+		case TLVRSA2048PSS, TLVECDSA224, TLVECDSASig, TLVRSA3072PSS, TLVED25519:
+			signatures = append(signatures, tlv)
+		}
+	}
+
+	return signatures
+}
+
+// Dependencies returns images this image depends on.
+func (i Image) Dependencies() ([]Dependency, error) {
+	var deps []Dependency
+	for _, tlv := range i.TLVs {
+		if tlv.Type != TLVDependency {
+			continue
+		}
+
+		if len(tlv.Data) != dependencySize {
+			return nil, fmt.Errorf("dependency tlv has %d bytes, want %d", len(tlv.Data), dependencySize)
+		}
+
+		deps = append(deps, Dependency{
+			ImageID:    tlv.Data[0],
+			MinVersion: parseVersion(tlv.Data[4:]),
+		})
+	}
+
+	return deps, nil
+}
+
+// SecurityCounter returns security counter of the image, if it is set.
+func (i Image) SecurityCounter() (uint32, bool, error) {
+	value, ok := i.Find(TLVSecurityCounter)
+	if !ok {
+		return 0, false, nil
+	}
+
+	if len(value) != 4 {
+		return 0, false, fmt.Errorf("security counter tlv has %d bytes, want 4", len(value))
+	}
+
+	return binary.LittleEndian.Uint32(value), true, nil
+}
+
+// VerifyHash checks that image hash stored in TLVs matches
+// hash of the image: header, image itself and protected TLVs.
+//
+// Signatures are not verified.
+func (i Image) VerifyHash() error {
+	hashType, expected, ok := i.Hash()
+	if !ok {
+		return errors.New("image has no hash tlv")
+	}
+
+	var h hash.Hash
+	switch hashType {
+	case TLVSHA256:
+		h = sha256.New()
+	case TLVSHA384:
+		h = sha512.New384()
+	case TLVSHA512:
+		h = sha512.New()
+	}
+
+	hashedLen := int(i.Header.HdrSize) + int(i.Header.ImgSize) + int(i.Header.ProtectTLVSize)
+	if hashedLen > len(i.data) {
+		return errors.New("image data is not available, image must be created with Parse")
+	}
+
+	h.Write(i.data[:hashedLen])
+
+	if calculated := h.Sum(nil); !bytes.Equal(calculated, expected) {
+		return fmt.Errorf("image hash mismatch: stored %x, calculated %x", expected, calculated)
+	}
+
+	return nil
+}
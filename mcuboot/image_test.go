@@ -0,0 +1,171 @@
+package mcuboot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+// buildTestImage builds signed image the same way imgtool does,
+// with dependency and security counter in protected TLVs.
+func buildTestImage(t *testing.T) []byte {
+	t.Helper()
+
+	le := binary.LittleEndian
+
+	const hdrSize = 0x200
+
+	payload := bytes.Repeat([]byte{0xab, 0xcd}, 100)
+
+	tlv := func(tlvType uint16, data []byte) []byte {
+		return append(le.AppendUint16(le.AppendUint16(nil, tlvType), uint16(len(data))), data...)
+	}
+
+	dependency := []byte{1, 0, 0, 0, 2, 0, 3, 0, 4, 0, 0, 0}
+
+	protected := append(tlv(TLVDependency, dependency), tlv(TLVSecurityCounter, le.AppendUint32(nil, 7))...)
+	protected = append(le.AppendUint16(le.AppendUint16(nil, TLVProtectedInfoMagic), uint16(tlvInfoSize+len(protected))), protected...)
+
+	var img []byte
+	img = le.AppendUint32(img, ImageMagic)
+	img = le.AppendUint32(img, 0x10000)
+	img = le.AppendUint16(img, hdrSize)
+	img = le.AppendUint16(img, uint16(len(protected)))
+	img = le.AppendUint32(img, uint32(len(payload)))
+	img = le.AppendUint32(img, FlagRAMLoad)
+	img = append(img, 1, 2)
+	img = le.AppendUint16(img, 3)
+	img = le.AppendUint32(img, 42)
+	img = append(img, make([]byte, hdrSize-len(img))...)
+	img = append(img, payload...)
+	img = append(img, protected...)
+
+	hash := sha256.Sum256(img)
+
+	unprotected := append(tlv(TLVKeyHash, []byte{9, 9, 9, 9}), tlv(TLVSHA256, hash[:])...)
+	unprotected = append(unprotected, tlv(TLVECDSASig, []byte{1, 2, 3})...)
+	img = le.AppendUint16(img, TLVInfoMagic)
+	img = le.AppendUint16(img, uint16(tlvInfoSize+len(unprotected)))
+
+	return append(img, unprotected...)
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	data := buildTestImage(t)
+
+	img, err := Parse(data)
+	if err != nil {
+		t.Fatalf("parse image: %s", err.Error())
+	}
+
+	if img.Header.LoadAddr != 0x10000 || img.Header.HdrSize != 0x200 || img.Header.ImgSize != 200 || !img.Header.HasFlag(FlagRAMLoad) {
+		t.Fatalf("wrong header: %+v", img.Header)
+	}
+
+	if version := img.Header.Version.String(); version != "1.2.3+42" {
+		t.Fatalf("wrong version: %s", version)
+	}
+
+	if len(img.TLVs) != 5 || !img.TLVs[0].Protected || img.TLVs[2].Protected {
+		t.Fatalf("wrong tlvs: %+v", img.TLVs)
+	}
+
+	deps, err := img.Dependencies()
+	if err != nil || len(deps) != 1 || deps[0].ImageID != 1 || deps[0].MinVersion != (Version{Major: 2, Revision: 3, BuildNum: 4}) {
+		t.Fatalf("wrong dependencies: %+v, %v", deps, err)
+	}
+
+	counter, ok, err := img.SecurityCounter()
+	if err != nil || !ok || counter != 7 {
+		t.Fatalf("wrong security counter: %d, %t, %v", counter, ok, err)
+	}
+
+	keyHash, ok := img.KeyHash()
+	if !ok || !bytes.Equal(keyHash, []byte{9, 9, 9, 9}) {
+		t.Fatalf("wrong key hash: %v", keyHash)
+	}
+
+	if signatures := img.Signatures(); len(signatures) != 1 || signatures[0].Type != TLVECDSASig {
+		t.Fatalf("wrong signatures: %+v", signatures)
+	}
+
+	if hashType, _, ok := img.Hash(); !ok || hashType != TLVSHA256 {
+		t.Fatalf("wrong hash type: %d", hashType)
+	}
+
+	if err := img.VerifyHash(); err != nil {
+		t.Fatalf("verify hash: %s", err.Error())
+	}
+}
+
+func TestVerifyHashMismatch(t *testing.T) {
+	t.Parallel()
+
+	data := buildTestImage(t)
+	// Corrupt image payload.
+	data[0x200] ^= 0xff
+
+	img, err := Parse(data)
+	if err != nil {
+		t.Fatalf("parse image: %s", err.Error())
+	}
+
+	if err := img.VerifyHash(); err == nil || !strings.Contains(err.Error(), "hash mismatch") {
+		t.Fatalf("want hash mismatch error, got %v", err)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	valid := buildTestImage(t)
+
+	tests := []struct {
+		name   string
+		modify func(data []byte) []byte
+	}{
+		{
+			name:   "Too small",
+			modify: func(data []byte) []byte { return data[:HeaderSize-1] },
+		},
+		{
+			name: "Wrong magic",
+			modify: func(data []byte) []byte {
+				data[0] = 0
+
+				return data
+			},
+		},
+		{
+			name:   "Truncated image",
+			modify: func(data []byte) []byte { return data[:0x200+10] },
+		},
+		{
+			name:   "Truncated tlvs",
+			modify: func(data []byte) []byte { return data[:len(data)-2] },
+		},
+		{
+			name: "Wrong protected tlv size",
+			modify: func(data []byte) []byte {
+				binary.LittleEndian.PutUint16(data[10:], 4)
+
+				return data
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := test.modify(bytes.Clone(valid))
+			if _, err := Parse(data); err == nil {
+				t.Fatalf("want parse error")
+			}
+		})
+	}
+}
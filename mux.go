@@ -0,0 +1,103 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// ErrSeqInFlight is returned when request is sent with sequence number
+// of another request that still waits for its response.
+var ErrSeqInFlight = errors.New("sequence number is already in flight")
+
+// Mux routes responses to requests waiting for them by sequence number.
+//
+// It is meant to be used by transports that receive responses asynchronously:
+// transport calls [Mux.RoundTrip] for each sent request, and [Mux.Dispatch]
+// for each received response.
+type Mux struct {
+	inFlight map[uint8]chan SMPFrame
+	mu       sync.Mutex
+}
+
+func NewMux() *Mux {
+	return &Mux{
+		inFlight: make(map[uint8]chan SMPFrame),
+	}
+}
+
+// RoundTrip reserves sequence number, calls `write` to send the request
+// and waits for the response with the same sequence number.
+//
+// Context must have deadline set, so request will not wait forever.
+// On deadline ErrWaitTimeout is returned.
+func (m *Mux) RoundTrip(ctx context.Context, seq uint8, write func() error) (SMPFrame, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		return SMPFrame{}, errors.New("context must have deadline set for wait")
+	}
+
+	// Channel is buffered so dispatch will never block,
+	// even if nobody waits for the response anymore.
+	resp := make(chan SMPFrame, 1)
+
+	// Response must be expected before write,
+	// otherwise it can arrive before anyone waits for it.
+	m.mu.Lock()
+	if _, ok := m.inFlight[seq]; ok {
+		m.mu.Unlock()
+
+		return SMPFrame{}, fmt.Errorf("%w: %d", ErrSeqInFlight, seq)
+	}
+	m.inFlight[seq] = resp
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		// Response may have been already dispatched,
+		// and sequence number reserved by another request.
+		if m.inFlight[seq] == resp {
+			delete(m.inFlight, seq)
+		}
+	}()
+
+	if err := write(); err != nil {
+		return SMPFrame{}, err
+	}
+
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		if errors.Is(err, context.DeadlineExceeded) {
+			return SMPFrame{}, ErrWaitTimeout
+		}
+
+		return SMPFrame{}, err
+	case frame := <-resp:
+		return frame, nil
+	}
+}
+
+// Dispatch passes received response to the request waiting for it.
+//
+// Responses that nobody waits for (i.e. they arrived after request timed out)
+// are dropped.
+func (m *Mux) Dispatch(frame SMPFrame) {
+	seq := frame.Header.SequenceNum
+
+	m.mu.Lock()
+	resp, ok := m.inFlight[seq]
+	delete(m.inFlight, seq)
+	m.mu.Unlock()
+
+	if !ok {
+		slog.Warn("drop stale smp response", "seq", seq, "group", frame.Header.GroupID, "cmd", frame.Header.CommandID)
+
+		return
+	}
+
+	resp <- frame
+}
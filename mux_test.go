@@ -0,0 +1,101 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMux(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	mux := NewMux()
+
+	// Stale response must be dropped without blocking.
+	mux.Dispatch(SMPFrame{Header: SMPHeader{SequenceNum: 1}})
+
+	blocked := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		resp, err := mux.RoundTrip(ctx, 1, func() error {
+			close(blocked)
+
+			return nil
+		})
+		if err != nil {
+			t.Errorf("round trip: %s", err.Error())
+		}
+
+		if resp.Header.CommandID != 5 {
+			t.Errorf("wrong response: %+v", resp.Header)
+		}
+	}()
+
+	<-blocked
+
+	// Same sequence number must not be used while it is in flight.
+	_, err := mux.RoundTrip(ctx, 1, func() error {
+		t.Errorf("colliding request must not be written")
+
+		return nil
+	})
+	if !errors.Is(err, ErrSeqInFlight) {
+		t.Fatalf("expected collision error, got: %v", err)
+	}
+
+	mux.Dispatch(SMPFrame{Header: SMPHeader{SequenceNum: 1, CommandID: 5}})
+	<-done
+
+	// After response is received - sequence number can be used again.
+	shortCtx, shortCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer shortCancel()
+
+	_, err = mux.RoundTrip(shortCtx, 1, func() error { return nil })
+	if !errors.Is(err, ErrWaitTimeout) {
+		t.Fatalf("expected timeout, got: %v", err)
+	}
+}
+
+func TestClientSeqNum(t *testing.T) {
+	t.Parallel()
+
+	first, second := NewSMPClient(nil), NewSMPClient(nil)
+
+	for range 10 {
+		first.NextSeqNum()
+	}
+
+	if seq := second.NextSeqNum(); seq != 1 {
+		t.Fatalf("clients must not share sequence numbers, got: %d", seq)
+	}
+
+	// 255 must not be skipped on wrap-around.
+	first.seqNum.Store(254)
+	if seq := first.NextSeqNum(); seq != 255 {
+		t.Fatalf("want 255, got: %d", seq)
+	}
+
+	if seq := first.NextSeqNum(); seq != 0 {
+		t.Fatalf("want 0 after wrap-around, got: %d", seq)
+	}
+}
+
+func TestPackageCreateFrameSeqNum(t *testing.T) {
+	t.Parallel()
+
+	// Deprecated package-level function must still assign sequence numbers,
+	// so frames sent concurrently through Mux do not collide.
+	first := CreateFrame(SMPOpReadRequest, SMPGroupOS, SMPCmdEcho, nil)
+	second := CreateFrame(SMPOpReadRequest, SMPGroupOS, SMPCmdEcho, nil)
+
+	if first.Header.SequenceNum == second.Header.SequenceNum {
+		t.Fatalf("frames must have different sequence numbers, got: %d", first.Header.SequenceNum)
+	}
+}
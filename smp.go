@@ -1,14 +1,15 @@
 package smp
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
-// seqNum holds current sequence number to calculate the next one.
-//
-// FIXME: this variable should have better place.
-var seqNum atomic.Uint32
+// defaultSeqNum is the sequence number counter of deprecated package-level [CreateFrame].
+var defaultSeqNum atomic.Uint32
 
 // SMP Protocol Version constants
 const (
@@ -28,14 +29,21 @@ const (
 const (
 	SMPGroupOS          = 0x00
 	SMPGroupImage       = 0x01
-	SMPGroupEcho        = 0x02
+	SMPGroupStat        = 0x02
+	SMPGroupSettings    = 0x03
 	SMPGroupLog         = 0x04
-	SMPGroupTest        = 0x05
+	SMPGroupCrashTest   = 0x05
 	SMPGroupSplitImage  = 0x06
-	SMPGroupCrashTest   = 0x07
-	SMPGroupShell       = 0x08
-	SMPGroupFS          = 0x09
+	SMPGroupTest        = 0x07 // Runtime tests, "run" group in MCUmgr
+	SMPGroupFS          = 0x08
+	SMPGroupShell       = 0x09
+	SMPGroupEnumeration = 0x0A
+	SMPGroupZephyrBasic = 0x3F
 	SMPGroupUserDefined = 0x40
+
+	// Deprecated: group 2 is the statistics group, echo is a command of OS group.
+	// Use SMPGroupStat instead.
+	SMPGroupEcho = SMPGroupStat
 )
 
 // Command IDs for OS Group (Group 0)
@@ -61,18 +69,83 @@ const (
 	SMPCmdImageErase  = 0x05
 )
 
+// Command IDs for Statistics Group (Group 2)
+const (
+	SMPCmdStatShow = 0x00
+	SMPCmdStatList = 0x01
+)
+
+// Command IDs for Settings Group (Group 3)
+const (
+	SMPCmdSettingsReadWrite = 0x00
+	SMPCmdSettingsDelete    = 0x01
+	SMPCmdSettingsCommit    = 0x02
+	SMPCmdSettingsLoadSave  = 0x03
+)
+
+// Command IDs for Log Group (Group 4)
+const (
+	SMPCmdLogShow       = 0x00
+	SMPCmdLogClear      = 0x01
+	SMPCmdLogAppend     = 0x02
+	SMPCmdLogModuleList = 0x03
+	SMPCmdLogLevelList  = 0x04
+	SMPCmdLogList       = 0x05
+)
+
+// Command IDs for Crash Test Group (Group 5)
+const (
+	SMPCmdCrashTrigger = 0x00
+)
+
+// Command IDs for Runtime Test Group (Group 7)
+const (
+	SMPCmdTestRun  = 0x00
+	SMPCmdTestList = 0x01
+)
+
+// Command IDs for File System Group (Group 8)
+const (
+	SMPCmdFSFile      = 0x00
+	SMPCmdFSStatus    = 0x01
+	SMPCmdFSHash      = 0x02
+	SMPCmdFSHashTypes = 0x03
+	SMPCmdFSClose     = 0x04
+)
+
+// Command IDs for Shell Group (Group 9)
+const (
+	SMPCmdShellExec = 0x00
+)
+
+// Command IDs for Enumeration Group (Group 10)
+const (
+	SMPCmdEnumCount   = 0x00
+	SMPCmdEnumList    = 0x01
+	SMPCmdEnumSingle  = 0x02
+	SMPCmdEnumDetails = 0x03
+)
+
+// Command IDs for Zephyr Basic Group (Group 63)
+const (
+	SMPCmdZephyrBasicEraseStorage = 0x00
+)
+
 // Error codes
 const (
 	Success = 0x00
 )
 
+// SMPHeaderSize is the size of SMP header on the wire.
+const SMPHeaderSize = 8
+
 // SMP Frame Header
 type SMPHeader struct {
 	Version     uint8
 	Op          uint8
 	Flags       uint8
 	DataLength  uint16
-	GroupID     uint8
+	GroupID     uint16
 	SequenceNum uint8
 	CommandID   uint8
 }
@@ -86,39 +159,96 @@ type SMPFrame struct {
 // SMP Client encapsulates the SMP communication
 type SMPClient struct {
 	transport Transport
+	version   uint8
+	// seqNum holds current sequence number to calculate the next one.
+	seqNum atomic.Uint32
+
+	// groups holds registered user-defined groups.
+	groups   map[uint16]GroupHandler
+	groupsMu sync.RWMutex
+}
+
+// SMPClientConfig defines optional parameters of the client.
+type SMPClientConfig struct {
+	// Legacy makes client send SMP v1 frames,
+	// which is necessary for devices that do not support SMP v2.
+	//
+	// Responses of both versions are understood regardless of this setting.
+	Legacy bool
 }
 
 // NewSMPClient creates a new SMP client with the given transport
 func NewSMPClient(transport Transport) *SMPClient {
+	return NewSMPClientWithConfig(transport, SMPClientConfig{})
+}
+
+// NewSMPClientWithConfig creates a new SMP client with the given transport and config.
+func NewSMPClientWithConfig(transport Transport, cfg SMPClientConfig) *SMPClient {
+	version := uint8(SMPVersion2)
+	if cfg.Legacy {
+		version = SMPVersionLegacy
+	}
+
 	return &SMPClient{
 		transport: transport,
+		version:   version,
+		groups:    make(map[uint16]GroupHandler),
 	}
 }
 
-func NextSeqNum() uint8 {
-	// This can return 0 on wrap-around,
-	// but it does not look like it should be a problem.
+// CreateFrame creates a new SMP frame with the protocol version of the client
+// and the next sequence number of the client.
+func (c *SMPClient) CreateFrame(op uint8, groupID uint16, commandID uint8, data []byte) SMPFrame {
+	frame := newFrame(op, groupID, commandID, data)
+	frame.Header.Version = c.version
+	frame.Header.SequenceNum = c.NextSeqNum()
+
+	return frame
+}
+
+// NextSeqNum returns next sequence number of the client.
+//
+// Sequence numbers are per-client, so clients
+// of different devices do not affect each other.
+func (c *SMPClient) NextSeqNum() uint8 {
+	// Sequence number wraps around after 255,
+	// which is expected by the protocol.
 	//
 	// https://docs.zephyrproject.org/latest/services/device_mgmt/smp_protocol.html#frame-the-envelope
-	return uint8(seqNum.Add(1) % 0xff)
+	return uint8(c.seqNum.Add(1))
 }
 
 // CreateFrame creates a new SMP frame with the specified parameters.
 //
-// Sequence number is generated atomically, it is not possible to change
-// how it is generated currently.
+// Sequence number is taken from the package-level counter, see [NextSeqNum].
+// It is always possible to change its value on the frame before sending it.
 //
-// But it is always possible to change its value on the frame before sending it.
-func CreateFrame(op uint8, groupID uint8, commandID uint8, data []byte) SMPFrame {
+// Deprecated: sequence numbers of frames created by this function are shared
+// by all devices. Use [SMPClient.CreateFrame], which uses per-client sequence numbers.
+func CreateFrame(op uint8, groupID uint16, commandID uint8, data []byte) SMPFrame {
+	frame := newFrame(op, groupID, commandID, data)
+	frame.Header.SequenceNum = NextSeqNum()
+
+	return frame
+}
+
+// NextSeqNum returns next sequence number of the package-level counter.
+//
+// Deprecated: use [SMPClient.NextSeqNum], which is tracked per client.
+func NextSeqNum() uint8 {
+	return uint8(defaultSeqNum.Add(1))
+}
+
+// newFrame creates a new SMP frame without sequence number set.
+func newFrame(op uint8, groupID uint16, commandID uint8, data []byte) SMPFrame {
 	return SMPFrame{
 		Header: SMPHeader{
-			Version:     SMPVersion2,
-			Op:          op,
-			Flags:       0x00,
-			DataLength:  uint16(len(data)),
-			GroupID:     groupID,
-			SequenceNum: NextSeqNum(),
-			CommandID:   commandID,
+			Version:    SMPVersion2,
+			Op:         op,
+			Flags:      0x00,
+			DataLength: uint16(len(data)),
+			GroupID:    groupID,
+			CommandID:  commandID,
 		},
 		Data: data,
 	}
@@ -143,3 +273,66 @@ func (f *SMPFrame) ValidateFrame() error {
 
 	return nil
 }
+
+// sendRequest encodes request, sends it as SMP frame and decodes response into T.
+//
+// `name` is used only to provide context in errors.
+// Response is handled with [decodeResponse].
+func sendRequest[T any](ctx context.Context, c *SMPClient, name string, op uint8, groupID uint16, commandID uint8, req any) (T, error) {
+	var resp T
+
+	data, err := EncodeCBOR(req)
+	if err != nil {
+		return resp, fmt.Errorf("failed to encode %s request: %w", name, err)
+	}
+
+	frame := c.CreateFrame(op, groupID, commandID, data)
+
+	response, err := c.transport.Send(ctx, frame)
+	if err != nil {
+		return resp, fmt.Errorf("failed to send %s frame: %w", name, err)
+	}
+
+	return decodeResponse[T](name, response)
+}
+
+// withTimeout calls `fn` with context that is done after `timeout`.
+//
+// It is used by long-running operations that send
+// multiple requests, each of which must have a deadline.
+func withTimeout[T any](ctx context.Context, timeout time.Duration, fn func(ctx context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return fn(ctx)
+}
+
+// decodeResponse validates response frame and decodes it into T.
+//
+// If response contains error - it will be returned as wrapped [*SMPError],
+// and decoded response will be returned as well.
+func decodeResponse[T any](name string, response SMPFrame) (T, error) {
+	var resp T
+
+	if err := response.ValidateFrame(); err != nil {
+		return resp, fmt.Errorf("invalid %s response frame: %w", name, err)
+	}
+
+	// Status is decoded separately to not require
+	// each response type to define it.
+	status, err := DecodeCBOR[responseStatus](response.Data)
+	if err != nil {
+		return resp, fmt.Errorf("failed to parse %s response: %w", name, err)
+	}
+
+	resp, err = DecodeCBOR[T](response.Data)
+	if err != nil {
+		return resp, fmt.Errorf("failed to parse %s response: %w", name, err)
+	}
+
+	if smpErr := status.smpError(response.Header.Version); smpErr != nil {
+		return resp, fmt.Errorf("%s command failed: %w", name, smpErr)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,16 @@
+package smp
+
+import (
+	"context"
+)
+
+// SetConsoleEcho enables or disables echo of received characters on device console.
+//
+// Echo should be disabled before console UART is used for SMP
+// by serial transport, otherwise echoed data corrupts frames
+// on consoles shared with SMP.
+func (c *SMPClient) SetConsoleEcho(ctx context.Context, enabled bool) error {
+	_, err := sendRequest[ConsoleEchoResponse](ctx, c, "console echo", SMPOpWriteRequest, SMPGroupOS, SMPCmdConsole, BuildConsoleEchoRequest(enabled))
+
+	return err
+}
@@ -0,0 +1,63 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSetConsoleEcho(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	var echoes []int
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		if frame.Header.Op != SMPOpWriteRequest || frame.Header.GroupID != SMPGroupOS || frame.Header.CommandID != SMPCmdConsole {
+			t.Errorf("unexpected frame header: %+v", frame.Header)
+		}
+
+		req, err := DecodeCBOR[map[string]any](frame.Data)
+		if err != nil {
+			t.Errorf("decode request: %s", err.Error())
+		}
+
+		// Echo must be encoded as integer.
+		echo, ok := req["echo"].(uint64)
+		if !ok {
+			t.Errorf("echo is not an integer: %#v", req["echo"])
+		}
+
+		echoes = append(echoes, int(echo))
+
+		if len(echoes) == 3 {
+			return newTestResponse(frame, map[string]any{"rc": RcNotSupported}), nil
+		}
+
+		return newTestResponse(frame, map[string]any{}), nil
+	}
+
+	client := NewSMPClient(transport)
+
+	if err := client.SetConsoleEcho(ctx, false); err != nil {
+		t.Fatalf("disable console echo: %s", err.Error())
+	}
+
+	if err := client.SetConsoleEcho(ctx, true); err != nil {
+		t.Fatalf("enable console echo: %s", err.Error())
+	}
+
+	if !slices.Equal(echoes, []int{0, 1}) {
+		t.Fatalf("wrong echo values sent: %v", echoes)
+	}
+
+	err := client.SetConsoleEcho(ctx, false)
+	if !errors.Is(err, &SMPError{Rc: RcNotSupported, Legacy: true}) {
+		t.Fatalf("want not supported error, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Types of crashes that can be triggered with [SMPClient.TriggerCrash].
+const (
+	CrashDiv0     = "div0"   // Division by zero
+	CrashJump0    = "jump0"  // Jump to address zero
+	CrashRef0     = "ref0"   // Dereference of null pointer
+	CrashAssert   = "assert" // Failed assertion
+	CrashWatchdog = "wdog"   // Watchdog timeout
+)
+
+// TestRunAll is the test name that runs all registered runtime tests.
+const TestRunAll = "all"
+
+var crashTypes = []string{CrashDiv0, CrashJump0, CrashRef0, CrashAssert, CrashWatchdog}
+
+// TriggerCrash makes device crash in a way described by `crashType`,
+// which is one of Crash* types.
+//
+// Device crashes before it can respond, so request timeout
+// (ErrWaitTimeout) is not an error. Context deadline should be
+// short to not wait for response for long.
+func (c *SMPClient) TriggerCrash(ctx context.Context, crashType string) error {
+	if !slices.Contains(crashTypes, crashType) {
+		return fmt.Errorf("unknown crash type %q", crashType)
+	}
+
+	_, err := sendRequest[struct{}](ctx, c, "crash trigger", SMPOpWriteRequest, SMPGroupCrashTest, SMPCmdCrashTrigger, CrashTriggerRequest{T: crashType})
+	if errors.Is(err, ErrWaitTimeout) {
+		return nil
+	}
+
+	return err
+}
+
+// RunTest runs runtime test `name` on the device, or all of them if name is TestRunAll.
+//
+// `token` is optional, it is printed to device test log,
+// so results of this run can be found there.
+func (c *SMPClient) RunTest(ctx context.Context, name string, token string) error {
+	_, err := sendRequest[struct{}](ctx, c, "test run", SMPOpWriteRequest, SMPGroupTest, SMPCmdTestRun, TestRunRequest{Testname: name, Token: token})
+
+	return err
+}
+
+// ListTests returns names of runtime tests registered on the device.
+func (c *SMPClient) ListTests(ctx context.Context) ([]string, error) {
+	resp, err := sendRequest[TestListResponse](ctx, c, "test list", SMPOpReadRequest, SMPGroupTest, SMPCmdTestList, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.RunList, nil
+}
@@ -0,0 +1,100 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTriggerCrash(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	var crashed []string
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		if frame.Header.Op != SMPOpWriteRequest || frame.Header.GroupID != SMPGroupCrashTest || frame.Header.CommandID != SMPCmdCrashTrigger {
+			t.Errorf("unexpected frame header: %+v", frame.Header)
+		}
+
+		req, _ := DecodeCBOR[CrashTriggerRequest](frame.Data)
+		crashed = append(crashed, req.T)
+
+		if req.T == CrashAssert {
+			// Device is built without assertions.
+			return newTestResponse(frame, map[string]any{"rc": RcInvalid}), nil
+		}
+
+		// Device crashes without response.
+		return SMPFrame{}, ErrWaitTimeout
+	}
+
+	client := NewSMPClient(transport)
+
+	if err := client.TriggerCrash(ctx, CrashWatchdog); err != nil {
+		t.Fatalf("trigger crash: %s", err.Error())
+	}
+
+	err := client.TriggerCrash(ctx, CrashAssert)
+	if !errors.Is(err, &SMPError{Rc: RcInvalid, Legacy: true}) {
+		t.Fatalf("want invalid value error, got %v", err)
+	}
+
+	if err := client.TriggerCrash(ctx, "reboot"); err == nil {
+		t.Fatalf("want error for unknown crash type")
+	}
+
+	if !slices.Equal(crashed, []string{CrashWatchdog, CrashAssert}) {
+		t.Fatalf("wrong crashes triggered: %v", crashed)
+	}
+}
+
+func TestRunTest(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		if frame.Header.GroupID != SMPGroupTest {
+			t.Errorf("unexpected group: %d", frame.Header.GroupID)
+		}
+
+		if frame.Header.CommandID == SMPCmdTestList {
+			return newTestResponse(frame, TestListResponse{RunList: []string{"flash", "radio"}}), nil
+		}
+
+		req, _ := DecodeCBOR[TestRunRequest](frame.Data)
+		if req.Testname != TestRunAll && !slices.Contains([]string{"flash", "radio"}, req.Testname) {
+			return newTestResponse(frame, map[string]any{"rc": RcNoEntry}), nil
+		}
+
+		if req.Token != "release-1.2" {
+			t.Errorf("unexpected token: %q", req.Token)
+		}
+
+		return newTestResponse(frame, map[string]any{}), nil
+	}
+
+	client := NewSMPClient(transport)
+
+	tests, err := client.ListTests(ctx)
+	if err != nil || !slices.Equal(tests, []string{"flash", "radio"}) {
+		t.Fatalf("list tests: %v, %v", tests, err)
+	}
+
+	if err := client.RunTest(ctx, TestRunAll, "release-1.2"); err != nil {
+		t.Fatalf("run test: %s", err.Error())
+	}
+
+	err = client.RunTest(ctx, "missing", "release-1.2")
+	if !errors.Is(err, &SMPError{Rc: RcNoEntry, Legacy: true}) {
+		t.Fatalf("want no entry error, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// GroupHandler describes user-defined group,
+// so its commands can be called with [SMPClient.Call].
+type GroupHandler struct {
+	// Name of the group, used in errors.
+	Name string
+	// Commands of the group, by command ID.
+	Commands map[uint8]CommandHandler
+	// ReturnCodes maps group-specific return codes
+	// to human-readable names, which are used in [SMPError].
+	ReturnCodes map[int]string
+}
+
+// CommandHandler describes single command of user-defined group.
+type CommandHandler struct {
+	// Name of the command, used in errors.
+	Name string
+	// Op is the operation of the request,
+	// either SMPOpReadRequest or SMPOpWriteRequest.
+	Op uint8
+}
+
+// RegisterGroup registers user-defined group on the client.
+//
+// Group ID must not be less than SMPGroupUserDefined,
+// and each group can be registered only once.
+func (c *SMPClient) RegisterGroup(groupID uint16, group GroupHandler) error {
+	if groupID < SMPGroupUserDefined {
+		return fmt.Errorf("group id %d is reserved, user-defined groups start from %d", groupID, SMPGroupUserDefined)
+	}
+
+	for id, cmd := range group.Commands {
+		if cmd.Op != SMPOpReadRequest && cmd.Op != SMPOpWriteRequest {
+			return fmt.Errorf("command %d: op must be read or write request, got %d", id, cmd.Op)
+		}
+	}
+
+	c.groupsMu.Lock()
+	defer c.groupsMu.Unlock()
+
+	if _, ok := c.groups[groupID]; ok {
+		return fmt.Errorf("group %d is already registered", groupID)
+	}
+
+	c.groups[groupID] = group
+
+	return nil
+}
+
+// Call sends command of registered user-defined group
+// and decodes response into `resp`, which must be a pointer.
+//
+// If `req` is nil - empty map is sent, as devices expect request to be a map.
+//
+// If device responds with error - returned [SMPError]
+// will use return code names of the group.
+func (c *SMPClient) Call(ctx context.Context, groupID uint16, commandID uint8, req any, resp any) error {
+	c.groupsMu.RLock()
+	group, ok := c.groups[groupID]
+	c.groupsMu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("group %d is not registered", groupID)
+	}
+
+	cmd, ok := group.Commands[commandID]
+	if !ok {
+		return fmt.Errorf("command %d is not registered in group %q", commandID, group.Name)
+	}
+
+	name := group.Name + " " + cmd.Name
+
+	if req == nil {
+		req = struct{}{}
+	}
+
+	raw, err := sendRequest[cbor.RawMessage](ctx, c, name, cmd.Op, groupID, commandID, req)
+	if err != nil {
+		var smpErr *SMPError
+		if errors.As(err, &smpErr) && !smpErr.Legacy {
+			smpErr.rcName = group.ReturnCodes[smpErr.Rc]
+		}
+
+		return err
+	}
+
+	if resp == nil {
+		return nil
+	}
+
+	if err := cbor.Unmarshal(raw, resp); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", name, err)
+	}
+
+	return nil
+}
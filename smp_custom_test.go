@@ -0,0 +1,114 @@
+package smp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCustomGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	const groupID = 0x0123
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		if frame.Header.GroupID != groupID || frame.Header.Op != SMPOpReadRequest {
+			t.Errorf("unexpected frame header: %+v", frame.Header)
+		}
+
+		req, _ := DecodeCBOR[map[string]string](frame.Data)
+		if req["key"] == "missing" {
+			return newTestResponse(frame, map[string]any{
+				"err": map[string]any{"group": groupID, "rc": 2},
+			}), nil
+		}
+
+		return newTestResponse(frame, map[string]any{"value": req["key"] + "-value"}), nil
+	}
+
+	client := NewSMPClient(transport)
+
+	if err := client.RegisterGroup(SMPGroupImage, GroupHandler{}); err == nil {
+		t.Fatalf("must not allow to register built-in group")
+	}
+
+	err := client.RegisterGroup(groupID, GroupHandler{
+		Name: "vendor",
+		Commands: map[uint8]CommandHandler{
+			0: {Name: "get", Op: SMPOpReadRequest},
+		},
+		ReturnCodes: map[int]string{
+			2: "no such key",
+		},
+	})
+	if err != nil {
+		t.Fatalf("register group: %s", err.Error())
+	}
+
+	if err := client.RegisterGroup(groupID, GroupHandler{}); err == nil {
+		t.Fatalf("must not allow to register group twice")
+	}
+
+	var resp struct {
+		Value string `cbor:"value"`
+	}
+	if err := client.Call(ctx, groupID, 0, map[string]string{"key": "a"}, &resp); err != nil {
+		t.Fatalf("call: %s", err.Error())
+	}
+
+	if resp.Value != "a-value" {
+		t.Fatalf("wrong response: %+v", resp)
+	}
+
+	err = client.Call(ctx, groupID, 0, map[string]string{"key": "missing"}, &resp)
+
+	var smpErr *SMPError
+	if !errors.As(err, &smpErr) || smpErr.Name() != "no such key" {
+		t.Fatalf("wrong error: %v", err)
+	}
+
+	if err := client.Call(ctx, groupID, 1, nil, nil); err == nil {
+		t.Fatalf("must not allow to call unregistered command")
+	}
+}
+
+func TestCustomGroupNilRequest(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	const groupID = 0x0123
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		// Empty CBOR map, not null.
+		if !bytes.Equal(frame.Data, []byte{0xa0}) {
+			t.Errorf("request must be empty map, got: %x", frame.Data)
+		}
+
+		return newTestResponse(frame, map[string]any{}), nil
+	}
+
+	client := NewSMPClient(transport)
+
+	err := client.RegisterGroup(groupID, GroupHandler{
+		Name: "vendor",
+		Commands: map[uint8]CommandHandler{
+			1: {Name: "reset", Op: SMPOpWriteRequest},
+		},
+	})
+	if err != nil {
+		t.Fatalf("register group: %s", err.Error())
+	}
+
+	if err := client.Call(ctx, groupID, 1, nil, nil); err != nil {
+		t.Fatalf("call: %s", err.Error())
+	}
+}
@@ -0,0 +1,55 @@
+package smp
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// dateTimeLayout is the format device accepts date-time in.
+//
+// Time zone is not sent, as not all devices support it,
+// so time is always sent in UTC.
+const dateTimeLayout = "2006-01-02T15:04:05.000"
+
+// dateTimeLayoutNoTZ is used to parse date-time without time zone.
+const dateTimeLayoutNoTZ = "2006-01-02T15:04:05"
+
+// GetDateTime returns current date and time of the device RTC.
+//
+// If device does not report time zone - returned time is in UTC.
+func (c *SMPClient) GetDateTime(ctx context.Context) (time.Time, error) {
+	resp, err := sendRequest[DateTimeResponse](ctx, c, "datetime", SMPOpReadRequest, SMPGroupOS, SMPCmdDateTime, struct{}{})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := parseDateTime(resp.DateTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse datetime response: %w", err)
+	}
+
+	return t, nil
+}
+
+// SetDateTime sets date and time of the device RTC.
+//
+// Time is converted to UTC before sending.
+func (c *SMPClient) SetDateTime(ctx context.Context, t time.Time) error {
+	req := BuildDateTimeRequest(t.UTC().Format(dateTimeLayout))
+
+	_, err := sendRequest[struct{}](ctx, c, "datetime", SMPOpWriteRequest, SMPGroupOS, SMPCmdDateTime, req)
+
+	return err
+}
+
+// parseDateTime parses RFC 3339 date-time, with or without
+// time zone and fractional seconds.
+func parseDateTime(value string) (time.Time, error) {
+	// Fractional seconds are accepted by both layouts on parse.
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.ParseInLocation(dateTimeLayoutNoTZ, value, time.UTC)
+}
@@ -0,0 +1,65 @@
+package smp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseDateTime(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{value: "2024-03-05T10:20:30", want: time.Date(2024, 3, 5, 10, 20, 30, 0, time.UTC)},
+		{value: "2024-03-05T10:20:30.123", want: time.Date(2024, 3, 5, 10, 20, 30, 123e6, time.UTC)},
+		{value: "2024-03-05T10:20:30.123456", want: time.Date(2024, 3, 5, 10, 20, 30, 123456e3, time.UTC)},
+		{value: "2024-03-05T10:20:30Z", want: time.Date(2024, 3, 5, 10, 20, 30, 0, time.UTC)},
+		{value: "2024-03-05T12:20:30.500+02:00", want: time.Date(2024, 3, 5, 10, 20, 30, 500e6, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDateTime(tt.value)
+		if err != nil {
+			t.Fatalf("%s: parse: %s", tt.value, err.Error())
+		}
+
+		if !got.Equal(tt.want) {
+			t.Fatalf("%s: got %s, want %s", tt.value, got, tt.want)
+		}
+	}
+
+	if _, err := parseDateTime("05.03.2024 10:20"); err == nil {
+		t.Fatalf("expected error for invalid format")
+	}
+}
+
+func TestSetDateTime(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		req, err := DecodeCBOR[DateTimeRequest](frame.Data)
+		if err != nil {
+			t.Errorf("decode request: %s", err.Error())
+		}
+
+		if frame.Header.Op != SMPOpWriteRequest || req.DateTime != "2024-03-05T10:20:30.250" {
+			t.Errorf("unexpected request: %+v, %+v", frame.Header, req)
+		}
+
+		return newTestResponse(frame, map[string]any{}), nil
+	}
+
+	local := time.FixedZone("UTC+2", 2*60*60)
+
+	err := NewSMPClient(transport).SetDateTime(ctx, time.Date(2024, 3, 5, 12, 20, 30, 250e6, local))
+	if err != nil {
+		t.Fatalf("set datetime: %s", err.Error())
+	}
+}
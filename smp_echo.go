@@ -0,0 +1,80 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PingStats holds round-trip statistics of [SMPClient.Ping].
+type PingStats struct {
+	Count int
+	Min   time.Duration
+	Avg   time.Duration
+	Max   time.Duration
+	// Jitter is the mean difference between consecutive round-trips.
+	Jitter time.Duration
+}
+
+// Echo sends `data` to the device and returns what device responded with.
+func (c *SMPClient) Echo(ctx context.Context, data string) (string, error) {
+	resp, err := sendRequest[EchoResponse](ctx, c, "echo", SMPOpWriteRequest, SMPGroupOS, SMPCmdEcho, BuildEchoRequest(data))
+	if err != nil {
+		return "", err
+	}
+
+	return resp.R, nil
+}
+
+// Ping sends `count` echo requests sequentially and measures their round-trip time.
+//
+// It can be used to check that device is alive and to evaluate link quality
+// before long operations, like image upload.
+func (c *SMPClient) Ping(ctx context.Context, count int) (PingStats, error) {
+	if count <= 0 {
+		return PingStats{}, errors.New("count must be positive")
+	}
+
+	stats := PingStats{Count: count}
+
+	var total, jitterTotal, prev time.Duration
+	for i := range count {
+		payload := fmt.Sprintf("ping %d", i)
+
+		start := time.Now()
+
+		resp, err := c.Echo(ctx, payload)
+		if err != nil {
+			return PingStats{}, fmt.Errorf("ping %d: %w", i, err)
+		}
+
+		rtt := time.Since(start)
+
+		if resp != payload {
+			return PingStats{}, fmt.Errorf("ping %d: device responded with %q, want %q", i, resp, payload)
+		}
+
+		if i == 0 || rtt < stats.Min {
+			stats.Min = rtt
+		}
+
+		if rtt > stats.Max {
+			stats.Max = rtt
+		}
+
+		if i != 0 {
+			jitterTotal += (rtt - prev).Abs()
+		}
+
+		total += rtt
+		prev = rtt
+	}
+
+	stats.Avg = total / time.Duration(count)
+	if count > 1 {
+		stats.Jitter = jitterTotal / time.Duration(count-1)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,57 @@
+package smp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newEchoTestTransport(t *testing.T) *testTransport {
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		if frame.Header.GroupID != SMPGroupOS || frame.Header.CommandID != SMPCmdEcho {
+			t.Errorf("unexpected frame header: %+v", frame.Header)
+		}
+
+		req, err := DecodeCBOR[EchoRequest](frame.Data)
+		if err != nil {
+			t.Errorf("decode request: %s", err.Error())
+		}
+
+		return newTestResponse(frame, EchoResponse{R: req.D}), nil
+	}
+
+	return transport
+}
+
+func TestEcho(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	resp, err := NewSMPClient(newEchoTestTransport(t)).Echo(ctx, "hello")
+	if err != nil {
+		t.Fatalf("echo: %s", err.Error())
+	}
+
+	if resp != "hello" {
+		t.Fatalf("wrong echo response: %q", resp)
+	}
+}
+
+func TestPing(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	stats, err := NewSMPClient(newEchoTestTransport(t)).Ping(ctx, 5)
+	if err != nil {
+		t.Fatalf("ping: %s", err.Error())
+	}
+
+	if stats.Count != 5 || stats.Min > stats.Avg || stats.Avg > stats.Max {
+		t.Fatalf("inconsistent stats: %+v", stats)
+	}
+}
@@ -0,0 +1,81 @@
+package smp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Capabilities describes which groups device supports.
+type Capabilities struct {
+	// Groups supported by the device, in order reported by the device.
+	Groups []uint16
+}
+
+// Supports returns true if device supports group `groupID`.
+func (c Capabilities) Supports(groupID uint16) bool {
+	return slices.Contains(c.Groups, groupID)
+}
+
+// Capabilities returns groups supported by the device,
+// so support of optional groups (i.e. SMPGroupFS or SMPGroupShell)
+// can be checked before using them.
+//
+// Device must support Enumeration group.
+func (c *SMPClient) Capabilities(ctx context.Context) (Capabilities, error) {
+	groups, err := c.ListGroups(ctx)
+	if err != nil {
+		return Capabilities{}, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	return Capabilities{Groups: groups}, nil
+}
+
+// GroupCount returns number of groups supported by the device.
+func (c *SMPClient) GroupCount(ctx context.Context) (int, error) {
+	resp, err := sendRequest[EnumCountResponse](ctx, c, "enumeration count", SMPOpReadRequest, SMPGroupEnumeration, SMPCmdEnumCount, struct{}{})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(resp.Count), nil
+}
+
+// ListGroups returns IDs of groups supported by the device.
+func (c *SMPClient) ListGroups(ctx context.Context) ([]uint16, error) {
+	resp, err := sendRequest[EnumListResponse](ctx, c, "enumeration list", SMPOpReadRequest, SMPGroupEnumeration, SMPCmdEnumList, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Groups, nil
+}
+
+// GroupAt returns ID of the group at `index` in the list of supported groups,
+// and whether it is the last group.
+//
+// If index is out of range - device responds with RcEnumIndexTooLarge.
+func (c *SMPClient) GroupAt(ctx context.Context, index int) (uint16, bool, error) {
+	req := EnumSingleRequest{Index: uint16(index)}
+
+	resp, err := sendRequest[EnumSingleResponse](ctx, c, "enumeration single", SMPOpReadRequest, SMPGroupEnumeration, SMPCmdEnumSingle, req)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return resp.Group, resp.End, nil
+}
+
+// GroupDetails returns details of groups `groupIDs`, or of all groups if none are set.
+//
+// Groups that are not supported by the device are omitted.
+func (c *SMPClient) GroupDetails(ctx context.Context, groupIDs ...uint16) ([]GroupDetails, error) {
+	req := EnumDetailsRequest{Groups: groupIDs}
+
+	resp, err := sendRequest[EnumDetailsResponse](ctx, c, "enumeration details", SMPOpReadRequest, SMPGroupEnumeration, SMPCmdEnumDetails, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Groups, nil
+}
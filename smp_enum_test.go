@@ -0,0 +1,129 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newEnumTestTransport returns transport that emulates enumeration group
+// of device that supports groups `groups`.
+func newEnumTestTransport(t *testing.T, groups []uint16) *testTransport {
+	names := map[uint16]string{SMPGroupOS: "os", SMPGroupImage: "img", SMPGroupEnumeration: "enum"}
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		if frame.Header.Op != SMPOpReadRequest || frame.Header.GroupID != SMPGroupEnumeration {
+			t.Errorf("unexpected frame header: %+v", frame.Header)
+		}
+
+		switch frame.Header.CommandID {
+		case SMPCmdEnumCount:
+			return newTestResponse(frame, EnumCountResponse{Count: uint16(len(groups))}), nil
+		case SMPCmdEnumList:
+			return newTestResponse(frame, EnumListResponse{Groups: groups}), nil
+		case SMPCmdEnumSingle:
+			req, _ := DecodeCBOR[EnumSingleRequest](frame.Data)
+			if int(req.Index) >= len(groups) {
+				return newTestResponse(frame, map[string]any{
+					"err": map[string]any{"group": SMPGroupEnumeration, "rc": RcEnumIndexTooLarge},
+				}), nil
+			}
+
+			return newTestResponse(frame, EnumSingleResponse{Group: groups[req.Index], End: int(req.Index) == len(groups)-1}), nil
+		case SMPCmdEnumDetails:
+			req, _ := DecodeCBOR[EnumDetailsRequest](frame.Data)
+
+			var details []GroupDetails
+			for _, group := range groups {
+				if len(req.Groups) == 0 || slices.Contains(req.Groups, group) {
+					details = append(details, GroupDetails{Group: group, Name: names[group], Handlers: 3})
+				}
+			}
+
+			return newTestResponse(frame, EnumDetailsResponse{Groups: details}), nil
+		}
+
+		t.Errorf("unexpected command: %d", frame.Header.CommandID)
+
+		return SMPFrame{}, errors.New("unexpected request")
+	}
+
+	return transport
+}
+
+func TestCapabilities(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	client := NewSMPClient(newEnumTestTransport(t, []uint16{SMPGroupOS, SMPGroupImage, SMPGroupEnumeration}))
+
+	caps, err := client.Capabilities(ctx)
+	if err != nil {
+		t.Fatalf("capabilities: %s", err.Error())
+	}
+
+	if !caps.Supports(SMPGroupImage) || caps.Supports(SMPGroupFS) || caps.Supports(SMPGroupShell) {
+		t.Fatalf("wrong capabilities: %+v", caps)
+	}
+
+	count, err := client.GroupCount(ctx)
+	if err != nil || count != 3 {
+		t.Fatalf("group count: %d, %v", count, err)
+	}
+}
+
+func TestGroupAt(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	client := NewSMPClient(newEnumTestTransport(t, []uint16{SMPGroupOS, SMPGroupImage}))
+
+	group, end, err := client.GroupAt(ctx, 0)
+	if err != nil || group != SMPGroupOS || end {
+		t.Fatalf("group at 0: %d, %t, %v", group, end, err)
+	}
+
+	group, end, err = client.GroupAt(ctx, 1)
+	if err != nil || group != SMPGroupImage || !end {
+		t.Fatalf("group at 1: %d, %t, %v", group, end, err)
+	}
+
+	_, _, err = client.GroupAt(ctx, 2)
+	if !errors.Is(err, &SMPError{Group: SMPGroupEnumeration, Rc: RcEnumIndexTooLarge}) {
+		t.Fatalf("want index too large error, got %v", err)
+	}
+}
+
+func TestGroupDetails(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	client := NewSMPClient(newEnumTestTransport(t, []uint16{SMPGroupOS, SMPGroupImage, SMPGroupEnumeration}))
+
+	details, err := client.GroupDetails(ctx)
+	if err != nil {
+		t.Fatalf("group details: %s", err.Error())
+	}
+
+	if len(details) != 3 || details[1].Name != "img" || details[1].Handlers != 3 {
+		t.Fatalf("wrong details: %+v", details)
+	}
+
+	details, err = client.GroupDetails(ctx, SMPGroupEnumeration, SMPGroupFS)
+	if err != nil {
+		t.Fatalf("group details: %s", err.Error())
+	}
+
+	if len(details) != 1 || details[0].Group != SMPGroupEnumeration || details[0].Name != "enum" {
+		t.Fatalf("wrong details: %+v", details)
+	}
+}
@@ -0,0 +1,196 @@
+package smp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Hash and checksum types supported by File System group.
+//
+// Devices may support only some of them, see [SMPClient.FileHashTypes].
+const (
+	FileHashCRC32  = "crc32"
+	FileHashSHA256 = "sha256"
+)
+
+// Output formats of hash and checksum types.
+const (
+	FileHashFormatNumber = 0
+	FileHashFormatBytes  = 1
+)
+
+// FileHash is the result of [SMPClient.FileHash].
+type FileHash struct {
+	Type string
+	Off  uint64
+	Len  uint64
+	// Value of hash. Numeric checksums are converted to big-endian bytes.
+	Value []byte
+}
+
+// DownloadFile reads whole file `name` from the device.
+//
+// File is read in chunks, size of which is decided by the device.
+func (c *SMPClient) DownloadFile(ctx context.Context, name string) ([]byte, error) {
+	var data []byte
+	var total uint64
+
+	for {
+		req := FileDownloadRequest{Off: uint64(len(data)), Name: name}
+
+		resp, err := sendRequest[FileDownloadResponse](ctx, c, "file download", SMPOpReadRequest, SMPGroupFS, SMPCmdFSFile, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.Off != req.Off {
+			return nil, fmt.Errorf("device responded with offset %d, want %d", resp.Off, req.Off)
+		}
+
+		if req.Off == 0 {
+			if resp.Len == nil {
+				return nil, errors.New("device did not report file length")
+			}
+
+			total = *resp.Len
+			data = make([]byte, 0, total)
+		}
+
+		data = append(data, resp.Data...)
+
+		if uint64(len(data)) >= total {
+			return data, nil
+		}
+
+		if len(resp.Data) == 0 {
+			return nil, fmt.Errorf("device returned no data at offset %d of %d", req.Off, total)
+		}
+	}
+}
+
+// UploadFile writes `data` to the file `name` on the device.
+//
+// If `chunkSize` is zero - it will be derived from device parameters
+// and transport MTU, same as for image upload.
+//
+// Each next chunk is sent from the offset reported by the device.
+func (c *SMPClient) UploadFile(ctx context.Context, name string, data []byte, chunkSize int) error {
+	if chunkSize < 0 {
+		return errors.New("chunk size must not be negative")
+	}
+
+	if chunkSize == 0 {
+		params, err := c.MCUMgrParams(ctx)
+		if err != nil {
+			return fmt.Errorf("chunk size is not set and device parameters are not available: %w", err)
+		}
+
+		chunkSize = fileUploadChunkSize(c.frameSizeLimit(params), name, len(data))
+		if chunkSize <= 0 {
+			return fmt.Errorf("device buffer size %d is too small for upload", params.BufSize)
+		}
+	}
+
+	dataLen := uint64(len(data))
+
+	var off uint64
+	for {
+		req := FileUploadRequest{
+			Off:  off,
+			Data: data[off:min(off+uint64(chunkSize), dataLen)],
+			Name: name,
+		}
+		if off == 0 {
+			req.Len = &dataLen
+		}
+
+		resp, err := sendRequest[FileUploadResponse](ctx, c, "file upload", SMPOpWriteRequest, SMPGroupFS, SMPCmdFSFile, req)
+		if err != nil {
+			return err
+		}
+
+		if resp.Off <= off && len(req.Data) != 0 || resp.Off > dataLen {
+			return fmt.Errorf("device responded with unexpected offset %d after writing at %d", resp.Off, off)
+		}
+
+		off = resp.Off
+		if off == dataLen {
+			return nil
+		}
+	}
+}
+
+// fileUploadChunkSize returns maximum size of file data
+// that can be sent in the frame of `frameSize` bytes.
+func fileUploadChunkSize(frameSize int, name string, dataLen int) int {
+	length := uint64(dataLen)
+
+	encoded, err := EncodeCBOR(FileUploadRequest{Data: []byte{}, Name: name, Len: &length})
+	if err != nil {
+		return 0
+	}
+
+	// Offset of the following requests and length of data
+	// take more space than for this request: up to 8 more bytes each.
+	const encodingSlack = 16
+
+	return frameSize - SMPHeaderSize - len(encoded) - encodingSlack
+}
+
+// FileStatus returns size of the file `name`.
+func (c *SMPClient) FileStatus(ctx context.Context, name string) (uint64, error) {
+	resp, err := sendRequest[FileStatusResponse](ctx, c, "file status", SMPOpReadRequest, SMPGroupFS, SMPCmdFSStatus, FileStatusRequest{Name: name})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Len, nil
+}
+
+// FileHash calculates hash or checksum of the file `name` on the device.
+//
+// `hashType` is one of FileHash* types, if empty - device default is used.
+// If `length` is zero - data from `off` until the end of file is used.
+func (c *SMPClient) FileHash(ctx context.Context, name string, hashType string, off uint64, length uint64) (FileHash, error) {
+	req := FileHashRequest{Name: name, Type: hashType, Off: off, Len: length}
+
+	resp, err := sendRequest[FileHashResponse](ctx, c, "file hash", SMPOpReadRequest, SMPGroupFS, SMPCmdFSHash, req)
+	if err != nil {
+		return FileHash{}, err
+	}
+
+	hash := FileHash{Type: resp.Type, Off: resp.Off, Len: resp.Len}
+
+	// Output type depends on the hash type, so try both.
+	if value, err := DecodeCBOR[[]byte](resp.Output); err == nil {
+		hash.Value = value
+	} else if checksum, err := DecodeCBOR[uint32](resp.Output); err == nil {
+		hash.Value = binary.BigEndian.AppendUint32(nil, checksum)
+	} else {
+		return FileHash{}, fmt.Errorf("failed to parse file hash output: %w", err)
+	}
+
+	return hash, nil
+}
+
+// FileHashTypes returns hash and checksum types supported by the device, by name.
+func (c *SMPClient) FileHashTypes(ctx context.Context) (map[string]FileHashType, error) {
+	resp, err := sendRequest[FileHashTypesResponse](ctx, c, "file hash types", SMPOpReadRequest, SMPGroupFS, SMPCmdFSHashTypes, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Types, nil
+}
+
+// CloseFile closes file that device keeps open after upload or download.
+//
+// Device closes it on its own after timeout, but closing explicitly
+// allows other applications to access the file immediately.
+func (c *SMPClient) CloseFile(ctx context.Context) error {
+	_, err := sendRequest[struct{}](ctx, c, "file close", SMPOpWriteRequest, SMPGroupFS, SMPCmdFSClose, struct{}{})
+
+	return err
+}
@@ -0,0 +1,211 @@
+package smp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"hash/crc32"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newFSTestTransport returns transport that emulates file system group
+// with a single file, which is read in chunks of `readChunk` bytes.
+func newFSTestTransport(t *testing.T, file *[]byte, readChunk int) *testTransport {
+	var mu sync.Mutex
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if frame.Header.GroupID != SMPGroupFS {
+			t.Errorf("unexpected group: %d", frame.Header.GroupID)
+		}
+
+		switch {
+		case frame.Header.CommandID == SMPCmdFSFile && frame.Header.Op == SMPOpReadRequest:
+			req, _ := DecodeCBOR[FileDownloadRequest](frame.Data)
+
+			resp := FileDownloadResponse{
+				Off:  req.Off,
+				Data: (*file)[req.Off:min(int(req.Off)+readChunk, len(*file))],
+			}
+			if req.Off == 0 {
+				length := uint64(len(*file))
+				resp.Len = &length
+			}
+
+			return newTestResponse(frame, resp), nil
+		case frame.Header.CommandID == SMPCmdFSFile && frame.Header.Op == SMPOpWriteRequest:
+			req, _ := DecodeCBOR[FileUploadRequest](frame.Data)
+
+			if req.Off == 0 {
+				if req.Len == nil {
+					t.Errorf("first upload request must have length")
+				}
+
+				*file = (*file)[:0]
+			}
+
+			if int(req.Off) != len(*file) {
+				return newTestResponse(frame, map[string]any{"rc": RcBadState}), nil
+			}
+
+			*file = append(*file, req.Data...)
+
+			return newTestResponse(frame, FileUploadResponse{Off: uint64(len(*file))}), nil
+		case frame.Header.CommandID == SMPCmdFSStatus:
+			req, _ := DecodeCBOR[FileStatusRequest](frame.Data)
+			if req.Name != "/lfs/file" {
+				return newTestResponse(frame, map[string]any{
+					"err": map[string]any{"group": SMPGroupFS, "rc": RcFSFileNotFound},
+				}), nil
+			}
+
+			return newTestResponse(frame, FileStatusResponse{Len: uint64(len(*file))}), nil
+		case frame.Header.CommandID == SMPCmdFSHash:
+			req, _ := DecodeCBOR[FileHashRequest](frame.Data)
+
+			data := (*file)[req.Off:]
+			if req.Type == FileHashCRC32 {
+				return newTestResponse(frame, map[string]any{
+					"type": req.Type, "len": len(data), "output": crc32.ChecksumIEEE(data),
+				}), nil
+			}
+
+			return newTestResponse(frame, map[string]any{
+				"type": FileHashSHA256, "len": len(data), "output": []byte{1, 2, 3},
+			}), nil
+		case frame.Header.CommandID == SMPCmdFSHashTypes:
+			return newTestResponse(frame, map[string]any{
+				"types": map[string]any{
+					FileHashCRC32:  map[string]any{"format": FileHashFormatNumber, "size": 4},
+					FileHashSHA256: map[string]any{"format": FileHashFormatBytes, "size": 32},
+				},
+			}), nil
+		case frame.Header.CommandID == SMPCmdFSClose:
+			return newTestResponse(frame, map[string]any{}), nil
+		}
+
+		t.Errorf("unexpected frame header: %+v", frame.Header)
+
+		return SMPFrame{}, errors.New("unexpected request")
+	}
+
+	return transport
+}
+
+func TestFileDownloadUpload(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	var file []byte
+	client := NewSMPClient(newFSTestTransport(t, &file, 7))
+
+	data := bytes.Repeat([]byte("file content "), 10)
+
+	if err := client.UploadFile(ctx, "/lfs/file", data, 16); err != nil {
+		t.Fatalf("upload file: %s", err.Error())
+	}
+
+	if !bytes.Equal(file, data) {
+		t.Fatalf("device file does not match uploaded data: %q", file)
+	}
+
+	downloaded, err := client.DownloadFile(ctx, "/lfs/file")
+	if err != nil {
+		t.Fatalf("download file: %s", err.Error())
+	}
+
+	if !bytes.Equal(downloaded, data) {
+		t.Fatalf("downloaded file does not match: %q", downloaded)
+	}
+
+	if err := client.CloseFile(ctx); err != nil {
+		t.Fatalf("close file: %s", err.Error())
+	}
+}
+
+func TestFileDownloadEmpty(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	var file []byte
+
+	downloaded, err := NewSMPClient(newFSTestTransport(t, &file, 7)).DownloadFile(ctx, "/lfs/file")
+	if err != nil {
+		t.Fatalf("download file: %s", err.Error())
+	}
+
+	if len(downloaded) != 0 {
+		t.Fatalf("want empty file, got %q", downloaded)
+	}
+}
+
+func TestFileStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	file := []byte("12345")
+	client := NewSMPClient(newFSTestTransport(t, &file, 7))
+
+	length, err := client.FileStatus(ctx, "/lfs/file")
+	if err != nil {
+		t.Fatalf("file status: %s", err.Error())
+	}
+
+	if length != 5 {
+		t.Fatalf("want length 5, got %d", length)
+	}
+
+	_, err = client.FileStatus(ctx, "/lfs/missing")
+	if !errors.Is(err, &SMPError{Group: SMPGroupFS, Rc: RcFSFileNotFound}) {
+		t.Fatalf("want file not found error, got %v", err)
+	}
+}
+
+func TestFileHash(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	file := []byte("123456789")
+	client := NewSMPClient(newFSTestTransport(t, &file, 7))
+
+	checksum, err := client.FileHash(ctx, "/lfs/file", FileHashCRC32, 0, 0)
+	if err != nil {
+		t.Fatalf("file checksum: %s", err.Error())
+	}
+
+	// Check value of CRC32 for "123456789".
+	if !bytes.Equal(checksum.Value, []byte{0xcb, 0xf4, 0x39, 0x26}) || checksum.Len != 9 {
+		t.Fatalf("wrong checksum: %+v", checksum)
+	}
+
+	hash, err := client.FileHash(ctx, "/lfs/file", "", 0, 0)
+	if err != nil {
+		t.Fatalf("file hash: %s", err.Error())
+	}
+
+	if hash.Type != FileHashSHA256 || !bytes.Equal(hash.Value, []byte{1, 2, 3}) {
+		t.Fatalf("wrong hash: %+v", hash)
+	}
+
+	types, err := client.FileHashTypes(ctx)
+	if err != nil {
+		t.Fatalf("file hash types: %s", err.Error())
+	}
+
+	if types[FileHashCRC32].Format != FileHashFormatNumber || types[FileHashSHA256].Size != 32 {
+		t.Fatalf("wrong hash types: %+v", types)
+	}
+}
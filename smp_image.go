@@ -14,6 +14,120 @@ const DefaultMaxWindowCount = 5
 
 type ImageChunkUploadCallbackFn func(frame FirmwareUploadRequest)
 
+// ImageUploadOptions defines how image should be uploaded.
+type ImageUploadOptions struct {
+	// Image is the number of the image to upload.
+	// Zero is the default image, on multi-image devices
+	// other numbers target other images (i.e. network core on nRF5340).
+	Image uint32
+	// Upgrade requests device to accept the image only if
+	// its version is greater than version of currently running image.
+	Upgrade bool
+
+	// MaxWindows is the maximum number of requests that can be in-flight at once.
+	// If not set - it is derived from device parameters when ChunkSize is not set,
+	// otherwise DefaultMaxWindowCount is used.
+	MaxWindows int
+	// ChunkSize is the maximum size of image data in one request.
+	// If not set - it is derived from device parameters
+	// and transport MTU (see [MTUTransport]).
+	ChunkSize int
+
+	// Resume will ask the device which offset it already has for the image
+	// and upload only the remaining part. If device does not have
+	// previous upload of the same image - upload will start from the beginning.
+	Resume bool
+
+	// Callback is called after each successfully uploaded chunk.
+	// For resumed uploads it is called only for chunks that were actually uploaded.
+	Callback ImageChunkUploadCallbackFn
+}
+
+// UploadImage uploads image to the device with provided options.
+func (c *SMPClient) UploadImage(ctx context.Context, data []byte, opts ImageUploadOptions) error {
+	if opts.ChunkSize < 0 {
+		return errors.New("chunk size must not be negative")
+	}
+
+	if opts.ChunkSize == 0 {
+		params, err := c.MCUMgrParams(ctx)
+		if err != nil {
+			return fmt.Errorf("chunk size is not set and device parameters are not available: %w", err)
+		}
+
+		opts.ChunkSize = uploadChunkSize(c.frameSizeLimit(params), len(data), opts)
+		if opts.ChunkSize <= 0 {
+			return fmt.Errorf("device buffer size %d is too small for upload", params.BufSize)
+		}
+
+		if opts.MaxWindows <= 0 {
+			opts.MaxWindows = max(int(params.BufCount), 1)
+		}
+
+		slog.Debug("derived upload parameters", "chunkSize", opts.ChunkSize, "maxWindows", opts.MaxWindows)
+	}
+
+	if opts.MaxWindows <= 0 {
+		opts.MaxWindows = DefaultMaxWindowCount
+	}
+
+	var offset int
+	if opts.Resume {
+		var err error
+		offset, err = c.imageUploadOffset(ctx, data, opts)
+		if err != nil {
+			return fmt.Errorf("query upload offset: %w", err)
+		}
+
+		if offset >= len(data) {
+			return nil
+		}
+
+		if offset != 0 {
+			slog.Info("resume image upload", "offset", offset, "len", len(data))
+		}
+	}
+
+	chunker := newChunker(c, opts.MaxWindows, data, opts.ChunkSize, opts.Callback)
+	chunker.startOffset = offset
+	chunker.image = opts.Image
+	chunker.upgrade = opts.Upgrade
+
+	return chunker.run(ctx)
+}
+
+// frameSizeLimit returns maximum size of the frame that
+// both device and transport can handle.
+func (c *SMPClient) frameSizeLimit(params MCUMgrParamsResponse) int {
+	limit := int(params.BufSize)
+
+	if mtuTransport, ok := c.transport.(MTUTransport); ok {
+		if mtu := mtuTransport.MTU(); mtu > 0 {
+			limit = min(limit, mtu)
+		}
+	}
+
+	return limit
+}
+
+// uploadChunkSize returns maximum size of image data
+// that can be sent in the frame of `frameSize` bytes.
+func uploadChunkSize(frameSize int, dataLen int, opts ImageUploadOptions) int {
+	// Worst case is the first request, as it also has image length and hash.
+	req := BuildFirmwareUploadRequest(opts.Image, uint32(dataLen), 0, make([]byte, sha256.Size), []byte{}, opts.Upgrade)
+
+	encoded, err := EncodeCBOR(req)
+	if err != nil {
+		return 0
+	}
+
+	// Offset of the following requests and length of data
+	// take more space than for this request: up to 4 more bytes each.
+	const encodingSlack = 8
+
+	return frameSize - SMPHeaderSize - len(encoded) - encodingSlack
+}
+
 // UploadImageWithWindows will do firmware upload with multiple windows.
 //
 // It will try to initiate up to `maxWindows` number of requests at once,
@@ -21,18 +135,68 @@ type ImageChunkUploadCallbackFn func(frame FirmwareUploadRequest)
 //
 // If no parallel upload is necessary - set `maxWindows` to one.
 // In this case chunks will be uploaded sequentially.
+//
+// If `chunkSize` is zero - it will be derived from device parameters,
+// see [ImageUploadOptions.ChunkSize].
 func (c *SMPClient) UploadImageWithWindows(ctx context.Context, maxWindows int, data []byte, chunkSize int, cb ImageChunkUploadCallbackFn) error {
-	chunker := newChunker(c.transport, maxWindows, data, chunkSize, cb)
+	return c.UploadImage(ctx, data, ImageUploadOptions{
+		MaxWindows: maxWindows,
+		ChunkSize:  chunkSize,
+		Callback:   cb,
+	})
+}
 
-	return chunker.run(ctx)
+// ResumeImageUpload continues previously interrupted image upload.
+//
+// It is the same as [SMPClient.UploadImageWithWindows],
+// but with [ImageUploadOptions.Resume] set.
+func (c *SMPClient) ResumeImageUpload(ctx context.Context, maxWindows int, data []byte, chunkSize int, cb ImageChunkUploadCallbackFn) error {
+	return c.UploadImage(ctx, data, ImageUploadOptions{
+		MaxWindows: maxWindows,
+		ChunkSize:  chunkSize,
+		Resume:     true,
+		Callback:   cb,
+	})
+}
+
+// imageUploadOffset returns offset from which device expects to continue upload of `data`.
+//
+// It sends first upload request with image length and hash, but without any data.
+// If device has unfinished upload with the same hash - it will respond with
+// offset of already written data, otherwise it will start new upload from zero.
+func (c *SMPClient) imageUploadOffset(ctx context.Context, data []byte, opts ImageUploadOptions) (int, error) {
+	sha := sha256.Sum256(data)
+
+	req := BuildFirmwareUploadRequest(opts.Image, uint32(len(data)), 0, sha[:], []byte{}, opts.Upgrade)
+
+	resp, err := sendRequest[FirmwareUploadResponse](ctx, c, "firmware upload", SMPOpWriteRequest, SMPGroupImage, SMPCmdImageUpload, req)
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.Match {
+		// Device already has the full image.
+		return len(data), nil
+	}
+
+	if int(resp.Off) > len(data) {
+		return 0, fmt.Errorf("device reported offset %d past image length %d", resp.Off, len(data))
+	}
+
+	return int(resp.Off), nil
 }
 
 type imgChunker struct {
-	transport Transport
+	client *SMPClient
 
 	data      []byte
 	chunkSize int
 	cb        ImageChunkUploadCallbackFn
+	// startOffset is the offset of the first chunk to upload.
+	// Data before it is considered to be already on the device.
+	startOffset int
+	image       uint32
+	upgrade     bool
 
 	currentWindows        atomic.Int32
 	currentAllowedWindows atomic.Int32
@@ -48,9 +212,9 @@ type imgChunker struct {
 	wg           sync.WaitGroup
 }
 
-func newChunker(transport Transport, maxWindows int, data []byte, chunkSize int, cb ImageChunkUploadCallbackFn) *imgChunker {
+func newChunker(client *SMPClient, maxWindows int, data []byte, chunkSize int, cb ImageChunkUploadCallbackFn) *imgChunker {
 	chunker := &imgChunker{
-		transport: transport,
+		client: client,
 
 		data:      data,
 		chunkSize: chunkSize,
@@ -76,7 +240,7 @@ func (c *imgChunker) run(ctx context.Context) error {
 
 	dataLen := len(c.data)
 
-	var currOffset int
+	currOffset := c.startOffset
 	for currOffset < dataLen {
 		c.chunkOffsets = append(c.chunkOffsets, currOffset)
 		currOffset += c.chunkSize
@@ -152,7 +316,7 @@ func (c *imgChunker) sendChunk(ctx context.Context, offset int) error {
 
 	nextPtr := min(offset+c.chunkSize, dataLen)
 
-	req := BuildFirmwareUploadRequest(0, uint32(dataLen), uint32(offset), shaVal, c.data[offset:nextPtr], false)
+	req := BuildFirmwareUploadRequest(c.image, uint32(dataLen), uint32(offset), shaVal, c.data[offset:nextPtr], c.upgrade)
 	uploadData, err := EncodeCBOR(req)
 	if err != nil {
 		return fmt.Errorf("failed to encode firmware upload request: %w", err)
@@ -168,10 +332,10 @@ func (c *imgChunker) sendChunk(ctx context.Context, offset int) error {
 		}
 
 		// Create SMP frame for firmware upload command
-		frame := CreateFrame(SMPOpWriteRequest, SMPGroupImage, SMPCmdImageUpload, uploadData)
+		frame := c.client.CreateFrame(SMPOpWriteRequest, SMPGroupImage, SMPCmdImageUpload, uploadData)
 
 		// Send the frame
-		response, err := c.transport.Send(ctx, frame)
+		response, err := c.client.transport.Send(ctx, frame)
 		switch {
 		case errors.Is(err, context.DeadlineExceeded):
 			// If we got timeout here - try to remove one window, if we have space for it.
@@ -189,20 +353,8 @@ func (c *imgChunker) sendChunk(ctx context.Context, offset int) error {
 			return fmt.Errorf("failed to send firmware upload frame: %w", err)
 		}
 
-		// Validate response
-		if err := response.ValidateFrame(); err != nil {
-			return fmt.Errorf("invalid firmware upload response frame: %w", err)
-		}
-
-		// Parse response
-		uploadResp, err := DecodeCBOR[FirmwareUploadResponse](response.Data)
-		if err != nil {
-			return fmt.Errorf("failed to parse firmware upload response: %w", err)
-		}
-
-		// Check for errors in response
-		if uploadResp.Err.Rc != 0 {
-			return fmt.Errorf("firmware upload command failed: group=%d, rc=%d", uploadResp.Err.Group, uploadResp.Err.Rc)
+		if _, err := decodeResponse[FirmwareUploadResponse]("firmware upload", response); err != nil {
+			return err
 		}
 
 		if c.cb != nil {
@@ -0,0 +1,18 @@
+package smp
+
+import (
+	"context"
+)
+
+// EraseImage erases image in the slot.
+//
+// If `slot` is nil - device will erase its default slot,
+// which normally is the secondary one.
+//
+// If device refuses to erase the slot (i.e. it is active one)
+// - returned error will wrap [*SMPError] with the reason.
+func (c *SMPClient) EraseImage(ctx context.Context, slot *uint32) error {
+	_, err := sendRequest[ImageEraseResponse](ctx, c, "image erase", SMPOpWriteRequest, SMPGroupImage, SMPCmdImageErase, BuildImageEraseRequest(slot))
+
+	return err
+}
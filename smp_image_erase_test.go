@@ -0,0 +1,45 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEraseImage(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	slot := uint32(0)
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		req, err := DecodeCBOR[ImageEraseRequest](frame.Data)
+		if err != nil {
+			t.Errorf("decode request: %s", err.Error())
+		}
+
+		if frame.Header.CommandID != SMPCmdImageErase || req.Slot == nil || *req.Slot != slot {
+			t.Errorf("unexpected request: %+v, %+v", frame.Header, req)
+		}
+
+		// Device refuses to erase the slot.
+		return newTestResponse(frame, map[string]any{
+			"err": map[string]any{"group": SMPGroupImage, "rc": RcImageInvalidSlot},
+		}), nil
+	}
+
+	err := NewSMPClient(transport).EraseImage(ctx, &slot)
+
+	var smpErr *SMPError
+	if !errors.As(err, &smpErr) {
+		t.Fatalf("expected smp error, got: %v", err)
+	}
+
+	if !errors.Is(err, &SMPError{Group: SMPGroupImage, Rc: RcImageInvalidSlot}) {
+		t.Fatalf("wrong error: %+v", smpErr)
+	}
+}
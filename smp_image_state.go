@@ -0,0 +1,66 @@
+package smp
+
+import (
+	"context"
+	"fmt"
+)
+
+// ReadImageState returns information about images in all slots of the device.
+func (c *SMPClient) ReadImageState(ctx context.Context) ([]ImageInfo, error) {
+	resp, err := sendRequest[ImageStateResponse](ctx, c, "image state", SMPOpReadRequest, SMPGroupImage, SMPCmdImageState, BuildImageStateRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Images, nil
+}
+
+// SetImageState changes state of the image and returns updated images information.
+//
+// If `confirm` is false - image with `hash` will be marked as pending for test,
+// and will be swapped on next reboot. If after reboot image is not confirmed -
+// bootloader will revert to the previous image.
+//
+// If `confirm` is true - image with `hash` will be marked as permanent.
+// If `hash` is empty - currently running image will be confirmed,
+// which is what should be done after test boot of new image.
+func (c *SMPClient) SetImageState(ctx context.Context, hash []byte, confirm bool) ([]ImageInfo, error) {
+	resp, err := sendRequest[ImageStateResponse](ctx, c, "image state", SMPOpWriteRequest, SMPGroupImage, SMPCmdImageState, BuildImageStateSetRequest(hash, confirm))
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Images, nil
+}
+
+// TestImage marks image uploaded to the secondary slot of `image`
+// as pending for test, so it will be booted on next reset.
+//
+// For single-image devices `image` should be zero.
+func (c *SMPClient) TestImage(ctx context.Context, image uint32) ([]ImageInfo, error) {
+	images, err := c.ReadImageState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secondary, ok := FindImage(images, image, 1)
+	if !ok {
+		return nil, fmt.Errorf("no uploaded image found for image %d", image)
+	}
+
+	return c.SetImageState(ctx, secondary.Hash, false)
+}
+
+// FindImage returns information about `slot` of the `image`.
+//
+// On multi-image devices slot numbers are per-image,
+// so both image and slot are needed to identify it.
+func FindImage(images []ImageInfo, image uint32, slot uint32) (ImageInfo, bool) {
+	for _, info := range images {
+		if info.ImageNumber() == image && info.Slot == slot {
+			return info, true
+		}
+	}
+
+	return ImageInfo{}, false
+}
@@ -0,0 +1,122 @@
+package smp
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+// newTestResponse creates response frame for the request with encoded `data`.
+func newTestResponse(req SMPFrame, data any) SMPFrame {
+	encoded, _ := EncodeCBOR(data)
+
+	resp := req
+	resp.Header.Op = req.Header.Op + 1
+	resp.Header.DataLength = uint16(len(encoded))
+	resp.Data = encoded
+
+	return resp
+}
+
+func TestReadImageState(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		if frame.Header.Op != SMPOpReadRequest || frame.Header.GroupID != SMPGroupImage || frame.Header.CommandID != SMPCmdImageState {
+			t.Errorf("unexpected frame header: %+v", frame.Header)
+		}
+
+		return newTestResponse(frame, map[string]any{
+			"images": []map[string]any{
+				{"slot": 0, "version": "1.0.0", "hash": []byte{1, 2}, "active": true, "confirmed": true},
+				{"slot": 1, "version": "1.1.0", "hash": []byte{3, 4}, "pending": true},
+			},
+		}), nil
+	}
+
+	images, err := NewSMPClient(transport).ReadImageState(ctx)
+	if err != nil {
+		t.Fatalf("read image state: %s", err.Error())
+	}
+
+	if len(images) != 2 {
+		t.Fatalf("want 2 images, got %d", len(images))
+	}
+
+	if images[1].Slot != 1 || images[1].Version != "1.1.0" || images[1].Pending == nil || !*images[1].Pending {
+		t.Fatalf("wrong second image: %+v", images[1])
+	}
+}
+
+func TestSetImageState(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	hash := []byte{3, 4}
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		req, err := DecodeCBOR[ImageStateSetRequest](frame.Data)
+		if err != nil {
+			t.Errorf("decode request: %s", err.Error())
+		}
+
+		if frame.Header.Op != SMPOpWriteRequest || !bytes.Equal(req.Hash, hash) || req.Confirm {
+			t.Errorf("unexpected request: %+v, %+v", frame.Header, req)
+		}
+
+		return newTestResponse(frame, map[string]any{
+			"err": map[string]any{"group": SMPGroupImage, "rc": 2},
+		}), nil
+	}
+
+	_, err := NewSMPClient(transport).SetImageState(ctx, hash, false)
+	if err == nil {
+		t.Fatalf("expected error from device")
+	}
+}
+
+func TestTestImageMultiImage(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	images := []map[string]any{
+		{"image": 0, "slot": 0, "version": "1.0.0", "hash": []byte{1}, "active": true},
+		{"image": 0, "slot": 1, "version": "1.1.0", "hash": []byte{2}},
+		{"image": 1, "slot": 0, "version": "1.0.0", "hash": []byte{3}, "active": true},
+		{"image": 1, "slot": 1, "version": "1.1.0", "hash": []byte{4}},
+	}
+
+	var setHash []byte
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		if frame.Header.Op == SMPOpWriteRequest {
+			req, err := DecodeCBOR[ImageStateSetRequest](frame.Data)
+			if err != nil {
+				t.Errorf("decode request: %s", err.Error())
+			}
+
+			setHash = req.Hash
+		}
+
+		return newTestResponse(frame, map[string]any{"images": images}), nil
+	}
+
+	if _, err := NewSMPClient(transport).TestImage(ctx, 1); err != nil {
+		t.Fatalf("test image: %s", err.Error())
+	}
+
+	if !bytes.Equal(setHash, []byte{4}) {
+		t.Fatalf("wrong image marked for test: %v", setHash)
+	}
+}
@@ -222,7 +222,7 @@ func TestImgChunkerCorrectness(t *testing.T) {
 		t.Fatalf("generate data: %s", err.Error())
 	}
 
-	chunker := newChunker(transport, maxAllowedWindows, dataToUpload, chunkSize, nil)
+	chunker := newChunker(NewSMPClient(transport), maxAllowedWindows, dataToUpload, chunkSize, nil)
 
 	err := chunker.run(ctx)
 	if err != nil {
@@ -261,10 +261,173 @@ func BenchmarkImgUpload(b *testing.B) {
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		chunker := newChunker(transport, maxAllowedWindows, dataToUpload, chunkSize, nil)
+		chunker := newChunker(NewSMPClient(transport), maxAllowedWindows, dataToUpload, chunkSize, nil)
 
 		if err := chunker.run(ctx); err != nil {
 			b.Fatalf("must not error, but got: %s", err.Error())
 		}
 	}
 }
+
+func TestResumeImageUpload(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	const chunkSize = 16
+	const dataSize = 1024
+	// Device already has this much data from previous upload.
+	const deviceOffset = 512
+
+	dataToUpload := make([]byte, dataSize)
+	if _, err := rand.Read(dataToUpload); err != nil {
+		t.Fatalf("generate data: %s", err.Error())
+	}
+
+	var uploadedMu sync.Mutex
+	var uploadedSize int
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		req, err := DecodeCBOR[FirmwareUploadRequest](frame.Data)
+		if err != nil {
+			t.Errorf("decode request: %s", err.Error())
+		}
+
+		off := req.Off + uint32(len(req.Data))
+		switch {
+		case req.Off == 0 && len(req.Data) == 0:
+			// Offset query.
+			if req.Len != dataSize || len(req.SHA) == 0 {
+				t.Errorf("offset query must contain length and hash: %+v", req)
+			}
+
+			off = deviceOffset
+		case req.Off < deviceOffset:
+			t.Errorf("uploaded chunk that device already has: %d", req.Off)
+		}
+
+		return newTestResponse(frame, FirmwareUploadResponse{Off: off}), nil
+	}
+
+	cl := NewSMPClient(transport)
+	err := cl.ResumeImageUpload(ctx, 3, dataToUpload, chunkSize, func(frame FirmwareUploadRequest) {
+		uploadedMu.Lock()
+		defer uploadedMu.Unlock()
+
+		uploadedSize += len(frame.Data)
+	})
+	if err != nil {
+		t.Fatalf("resume upload: %s", err.Error())
+	}
+
+	if uploadedSize != dataSize-deviceOffset {
+		t.Fatalf("uploaded size different: %d != %d", uploadedSize, dataSize-deviceOffset)
+	}
+}
+
+func TestUploadImageOptions(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	dataToUpload := make([]byte, 64)
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		req, err := DecodeCBOR[FirmwareUploadRequest](frame.Data)
+		if err != nil {
+			t.Errorf("decode request: %s", err.Error())
+		}
+
+		if req.Off == 0 && (req.Image != 1 || !req.Upgrade) {
+			t.Errorf("first chunk must have image and upgrade set: %+v", req)
+		}
+
+		return newTestResponse(frame, FirmwareUploadResponse{Off: req.Off + uint32(len(req.Data))}), nil
+	}
+
+	err := NewSMPClient(transport).UploadImage(ctx, dataToUpload, ImageUploadOptions{
+		Image:     1,
+		Upgrade:   true,
+		ChunkSize: 16,
+	})
+	if err != nil {
+		t.Fatalf("upload: %s", err.Error())
+	}
+}
+
+type mtuTestTransport struct {
+	*testTransport
+	mtu int
+}
+
+// MTU implements [MTUTransport].
+func (t mtuTestTransport) MTU() int {
+	return t.mtu
+}
+
+func TestUploadImageAutoChunkSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		mtu          int
+		maxFrameSize int
+	}{
+		{name: "device buffer limit", maxFrameSize: 256},
+		{name: "transport mtu limit", mtu: 128, maxFrameSize: 128},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			t.Cleanup(cancel)
+
+			dataToUpload := make([]byte, 70000)
+			if _, err := rand.Read(dataToUpload); err != nil {
+				t.Fatalf("generate data: %s", err.Error())
+			}
+
+			transport := newDefaultTestTransport()
+			transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+				if frame.Header.CommandID == SMPCmdMCUMgrParams && frame.Header.GroupID == SMPGroupOS {
+					return newTestResponse(frame, MCUMgrParamsResponse{BufSize: 256, BufCount: 4}), nil
+				}
+
+				if size := SMPHeaderSize + len(frame.Data); size > tt.maxFrameSize {
+					t.Errorf("frame is too big: %d > %d", size, tt.maxFrameSize)
+				}
+
+				req, _ := DecodeCBOR[FirmwareUploadRequest](frame.Data)
+
+				return newTestResponse(frame, FirmwareUploadResponse{Off: req.Off + uint32(len(req.Data))}), nil
+			}
+
+			var client *SMPClient
+			if tt.mtu != 0 {
+				client = NewSMPClient(mtuTestTransport{testTransport: transport, mtu: tt.mtu})
+			} else {
+				client = NewSMPClient(transport)
+			}
+
+			var uploaded atomic.Int64
+			err := client.UploadImage(ctx, dataToUpload, ImageUploadOptions{
+				Callback: func(frame FirmwareUploadRequest) {
+					uploaded.Add(int64(len(frame.Data)))
+				},
+			})
+			if err != nil {
+				t.Fatalf("upload: %s", err.Error())
+			}
+
+			if int(uploaded.Load()) != len(dataToUpload) {
+				t.Fatalf("uploaded size different: %d != %d", uploaded.Load(), len(dataToUpload))
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Types of log entry messages.
+const (
+	LogEntryTypeString = "str"
+	LogEntryTypeCBOR   = "cbor"
+	LogEntryTypeBinary = "bin"
+)
+
+// LogShowOptions filters entries returned by [SMPClient.ShowLogs].
+type LogShowOptions struct {
+	// Name of the log. If empty - entries of all logs are returned.
+	Name string
+	// Index of the first entry to return.
+	Index uint32
+	// Since returns only entries newer than this time, if set.
+	Since time.Time
+}
+
+// LogFollowFn is called by [SMPClient.FollowLogs] for each new entry of log `log`.
+//
+// If function returns error - following is stopped.
+type LogFollowFn func(log string, entry LogEntry) error
+
+// ShowLogs returns log entries matching `opts`.
+//
+// Device may return only part of matching entries, if they do not fit
+// into response. Rest of them can be requested from returned NextIndex.
+func (c *SMPClient) ShowLogs(ctx context.Context, opts LogShowOptions) (LogShowResponse, error) {
+	req := LogShowRequest{LogName: opts.Name, Index: opts.Index}
+	if !opts.Since.IsZero() {
+		req.Ts = opts.Since.UnixMicro()
+	}
+
+	return sendRequest[LogShowResponse](ctx, c, "log show", SMPOpReadRequest, SMPGroupLog, SMPCmdLogShow, req)
+}
+
+// FollowLogs streams log entries to `fn`, starting from `opts`.
+//
+// When device reports no new entries - logs are checked again after `interval`.
+// Entries with index lower than already received ones are skipped.
+// Each request is done with `timeout`.
+//
+// Following continues until context is done or `fn` returns error,
+// either of which is returned.
+func (c *SMPClient) FollowLogs(ctx context.Context, opts LogShowOptions, interval time.Duration, timeout time.Duration, fn LogFollowFn) error {
+	if interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+
+	for {
+		resp, err := withTimeout(ctx, timeout, func(ctx context.Context) (LogShowResponse, error) {
+			return c.ShowLogs(ctx, opts)
+		})
+		if err != nil {
+			return err
+		}
+
+		// Device may return entries that were already delivered,
+		// i.e. if it ignores index when time filter is set.
+		next := opts.Index
+		for _, log := range resp.Logs {
+			for _, entry := range log.Entries {
+				if entry.Index < opts.Index {
+					continue
+				}
+
+				if err := fn(log.Name, entry); err != nil {
+					return err
+				}
+
+				next = max(next, entry.Index+1)
+			}
+		}
+
+		advanced := resp.NextIndex > opts.Index
+		opts.Index = max(next, resp.NextIndex)
+
+		// There may be more entries that did not fit into response.
+		if advanced && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// ClearLogs removes entries of all logs on the device.
+func (c *SMPClient) ClearLogs(ctx context.Context) error {
+	_, err := sendRequest[struct{}](ctx, c, "log clear", SMPOpWriteRequest, SMPGroupLog, SMPCmdLogClear, struct{}{})
+
+	return err
+}
+
+// LogModules returns IDs of log modules, by module name.
+func (c *SMPClient) LogModules(ctx context.Context) (map[string]int, error) {
+	resp, err := sendRequest[LogModuleListResponse](ctx, c, "log module list", SMPOpReadRequest, SMPGroupLog, SMPCmdLogModuleList, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.ModuleMap, nil
+}
+
+// LogLevels returns values of log levels, by level name.
+func (c *SMPClient) LogLevels(ctx context.Context) (map[string]int, error) {
+	resp, err := sendRequest[LogLevelListResponse](ctx, c, "log level list", SMPOpReadRequest, SMPGroupLog, SMPCmdLogLevelList, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.LevelMap, nil
+}
+
+// ListLogs returns names of logs available on the device.
+func (c *SMPClient) ListLogs(ctx context.Context) ([]string, error) {
+	resp, err := sendRequest[LogListResponse](ctx, c, "log list", SMPOpReadRequest, SMPGroupLog, SMPCmdLogList, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.LogList, nil
+}
@@ -0,0 +1,224 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// logTestDevice emulates log group with a single `app` log,
+// returning at most 2 entries per response.
+type logTestDevice struct {
+	mu      sync.Mutex
+	entries []LogEntry
+}
+
+func (d *logTestDevice) append(msg string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.entries = append(d.entries, LogEntry{
+		Msg:   LogMessage(msg),
+		Ts:    int64(len(d.entries)) * 1000,
+		Index: uint32(len(d.entries)),
+		Type:  LogEntryTypeString,
+	})
+}
+
+func (d *logTestDevice) transport(t *testing.T) *testTransport {
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		if frame.Header.GroupID != SMPGroupLog {
+			t.Errorf("unexpected group: %d", frame.Header.GroupID)
+		}
+
+		switch frame.Header.CommandID {
+		case SMPCmdLogShow:
+			req, _ := DecodeCBOR[LogShowRequest](frame.Data)
+
+			var entries []LogEntry
+			for _, entry := range d.entries {
+				if entry.Index >= req.Index && entry.Ts >= req.Ts && len(entries) < 2 {
+					entries = append(entries, entry)
+				}
+			}
+
+			nextIndex := req.Index
+			if len(entries) != 0 {
+				nextIndex = entries[len(entries)-1].Index + 1
+			}
+
+			// Message is sent as text string.
+			encodedEntries := make([]map[string]any, 0, len(entries))
+			for _, entry := range entries {
+				encodedEntries = append(encodedEntries, map[string]any{
+					"msg": string(entry.Msg), "ts": entry.Ts, "level": 1, "index": entry.Index, "module": 0, "type": entry.Type,
+				})
+			}
+
+			return newTestResponse(frame, map[string]any{
+				"next_index": nextIndex,
+				"logs":       []map[string]any{{"name": "app", "type": 1, "entries": encodedEntries}},
+			}), nil
+		case SMPCmdLogClear:
+			d.entries = nil
+
+			return newTestResponse(frame, map[string]any{}), nil
+		case SMPCmdLogModuleList:
+			return newTestResponse(frame, LogModuleListResponse{ModuleMap: map[string]int{"DEFAULT": 0, "OS": 1}}), nil
+		case SMPCmdLogLevelList:
+			return newTestResponse(frame, LogLevelListResponse{LevelMap: map[string]int{"DEBUG": 0, "INFO": 1}}), nil
+		case SMPCmdLogList:
+			return newTestResponse(frame, LogListResponse{LogList: []string{"app"}}), nil
+		}
+
+		t.Errorf("unexpected command: %d", frame.Header.CommandID)
+
+		return SMPFrame{}, errors.New("unexpected request")
+	}
+
+	return transport
+}
+
+func TestShowLogs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	var device logTestDevice
+	for _, msg := range []string{"boot", "connected", "disconnected"} {
+		device.append(msg)
+	}
+
+	client := NewSMPClient(device.transport(t))
+
+	resp, err := client.ShowLogs(ctx, LogShowOptions{Since: time.UnixMicro(1000)})
+	if err != nil {
+		t.Fatalf("show logs: %s", err.Error())
+	}
+
+	if resp.NextIndex != 3 || len(resp.Logs) != 1 || len(resp.Logs[0].Entries) != 2 {
+		t.Fatalf("wrong response: %+v", resp)
+	}
+
+	entry := resp.Logs[0].Entries[0]
+	if string(entry.Msg) != "connected" || entry.Index != 1 || !entry.Time().Equal(time.UnixMicro(1000)) {
+		t.Fatalf("wrong entry: %+v", entry)
+	}
+
+	if err := client.ClearLogs(ctx); err != nil {
+		t.Fatalf("clear logs: %s", err.Error())
+	}
+
+	resp, err = client.ShowLogs(ctx, LogShowOptions{})
+	if err != nil || len(resp.Logs[0].Entries) != 0 {
+		t.Fatalf("want no entries after clear: %+v, %v", resp, err)
+	}
+
+	modules, err := client.LogModules(ctx)
+	if err != nil || !maps.Equal(modules, map[string]int{"DEFAULT": 0, "OS": 1}) {
+		t.Fatalf("log modules: %v, %v", modules, err)
+	}
+
+	levels, err := client.LogLevels(ctx)
+	if err != nil || levels["INFO"] != 1 {
+		t.Fatalf("log levels: %v, %v", levels, err)
+	}
+
+	logs, err := client.ListLogs(ctx)
+	if err != nil || !slices.Equal(logs, []string{"app"}) {
+		t.Fatalf("log list: %v, %v", logs, err)
+	}
+}
+
+func TestFollowLogs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	var device logTestDevice
+	for _, msg := range []string{"a", "b", "c"} {
+		device.append(msg)
+	}
+
+	client := NewSMPClient(device.transport(t))
+
+	errDone := errors.New("done")
+
+	var received []string
+	err := client.FollowLogs(ctx, LogShowOptions{Index: 1}, time.Millisecond, time.Second, func(log string, entry LogEntry) error {
+		received = append(received, string(entry.Msg))
+
+		switch len(received) {
+		case 2:
+			// New entries are added while logs are followed.
+			go device.append("d")
+			go device.append("e")
+		case 4:
+			return errDone
+		}
+
+		return nil
+	})
+	if !errors.Is(err, errDone) {
+		t.Fatalf("want error from callback, got %v", err)
+	}
+
+	if len(received) != 4 || !slices.Equal(received[:2], []string{"b", "c"}) {
+		t.Fatalf("wrong entries received: %v", received)
+	}
+}
+
+func TestFollowLogsIndexNotAdvanced(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	t.Cleanup(cancel)
+
+	var requests atomic.Int32
+
+	// Device ignores requested index and does not advance next index.
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		requests.Add(1)
+
+		req, _ := DecodeCBOR[LogShowRequest](frame.Data)
+
+		return newTestResponse(frame, LogShowResponse{
+			NextIndex: req.Index,
+			Logs: []Log{{Name: "app", Entries: []LogEntry{
+				{Msg: LogMessage("a"), Index: 0},
+				{Msg: LogMessage("b"), Index: 1},
+			}}},
+		}), nil
+	}
+
+	var received []string
+	err := NewSMPClient(transport).FollowLogs(ctx, LogShowOptions{}, 10*time.Millisecond, time.Second, func(log string, entry LogEntry) error {
+		received = append(received, string(entry.Msg))
+
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want deadline exceeded, got %v", err)
+	}
+
+	if !slices.Equal(received, []string{"a", "b"}) {
+		t.Fatalf("entries must be delivered once, got: %v", received)
+	}
+
+	// Logs must be polled with interval, not in a busy loop.
+	if n := requests.Load(); n > 30 {
+		t.Fatalf("too many requests: %d", n)
+	}
+}
@@ -0,0 +1,11 @@
+package smp
+
+import (
+	"context"
+)
+
+// MCUMgrParams returns parameters of SMP server on the device:
+// size and number of its buffers.
+func (c *SMPClient) MCUMgrParams(ctx context.Context) (MCUMgrParamsResponse, error) {
+	return sendRequest[MCUMgrParamsResponse](ctx, c, "mcumgr params", SMPOpReadRequest, SMPGroupOS, SMPCmdMCUMgrParams, struct{}{})
+}
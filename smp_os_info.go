@@ -0,0 +1,184 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// OS info format characters, they can be combined in a single format string.
+const (
+	OSInfoKernelName    = "s"
+	OSInfoNodeName      = "n"
+	OSInfoKernelRelease = "r"
+	OSInfoKernelVersion = "v"
+	OSInfoBuildDateTime = "b"
+	OSInfoMachine       = "m"
+	OSInfoProcessor     = "p"
+	OSInfoPlatform      = "i"
+	OSInfoOS            = "o"
+	OSInfoAll           = "a"
+)
+
+// BootloaderMCUboot is the name MCUboot reports in bootloader info.
+const BootloaderMCUboot = "MCUboot"
+
+// MCUbootMode is the mode MCUboot was built with.
+type MCUbootMode int
+
+const (
+	MCUbootModeSingleSlot          MCUbootMode = 0
+	MCUbootModeSwapUsingScratch    MCUbootMode = 1
+	MCUbootModeOverwriteOnly       MCUbootMode = 2
+	MCUbootModeSwapUsingMove       MCUbootMode = 3
+	MCUbootModeDirectXIP           MCUbootMode = 4
+	MCUbootModeDirectXIPWithRevert MCUbootMode = 5
+	MCUbootModeRAMLoad             MCUbootMode = 6
+	MCUbootModeFirmwareLoader      MCUbootMode = 7
+	MCUbootModeSingleSlotRAMLoad   MCUbootMode = 8
+	MCUbootModeSwapUsingOffset     MCUbootMode = 9
+)
+
+var mcubootModeNames = map[MCUbootMode]string{
+	MCUbootModeSingleSlot:          "single slot",
+	MCUbootModeSwapUsingScratch:    "swap using scratch",
+	MCUbootModeOverwriteOnly:       "overwrite only",
+	MCUbootModeSwapUsingMove:       "swap using move",
+	MCUbootModeDirectXIP:           "direct-xip",
+	MCUbootModeDirectXIPWithRevert: "direct-xip with revert",
+	MCUbootModeRAMLoad:             "ram load",
+	MCUbootModeFirmwareLoader:      "firmware loader",
+	MCUbootModeSingleSlotRAMLoad:   "single slot ram load",
+	MCUbootModeSwapUsingOffset:     "swap using offset",
+}
+
+// String implements fmt.Stringer.
+func (m MCUbootMode) String() string {
+	if name, ok := mcubootModeNames[m]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown (%d)", int(m))
+}
+
+// IsSwap reports whether images are swapped between slots on upgrade.
+func (m MCUbootMode) IsSwap() bool {
+	return m == MCUbootModeSwapUsingScratch || m == MCUbootModeSwapUsingMove || m == MCUbootModeSwapUsingOffset
+}
+
+// IsDirectXIP reports whether images are executed in place from either slot.
+func (m MCUbootMode) IsDirectXIP() bool {
+	return m == MCUbootModeDirectXIP || m == MCUbootModeDirectXIPWithRevert
+}
+
+// OSInfo holds uname-like information about the device.
+//
+// Fields that device can not report are left empty.
+type OSInfo struct {
+	KernelName    string
+	NodeName      string
+	KernelRelease string
+	KernelVersion string
+	BuildDateTime string
+	Machine       string
+	Processor     string
+	Platform      string // Hardware platform, i.e. board name
+	OS            string
+}
+
+// BootloaderInfo holds information about device bootloader.
+type BootloaderInfo struct {
+	// Name of the bootloader, i.e. BootloaderMCUboot.
+	Name string
+	// Mode is set only for MCUboot.
+	Mode MCUbootMode
+	// NoDowngrade is true if bootloader will refuse
+	// to boot image with lower version. Set only for MCUboot.
+	NoDowngrade bool
+}
+
+// OSInfo returns uname-like information about the device.
+//
+// `format` is a combination of OSInfo* characters, i.e.
+// `OSInfoKernelName + OSInfoKernelRelease`. Values in output
+// are separated by space, in the order defined by device.
+func (c *SMPClient) OSInfo(ctx context.Context, format string) (string, error) {
+	resp, err := sendRequest[OSInfoResponse](ctx, c, "os info", SMPOpReadRequest, SMPGroupOS, SMPCmdOSInfo, BuildOSInfoRequest(format))
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Output, nil
+}
+
+// ReadOSInfo returns all information about the device OS.
+//
+// Each field is queried separately, as values may contain spaces
+// and can not be reliably split from combined output of [SMPClient.OSInfo].
+// Fields for which device has no answer are left empty.
+func (c *SMPClient) ReadOSInfo(ctx context.Context) (OSInfo, error) {
+	var info OSInfo
+
+	for _, field := range []struct {
+		format string
+		value  *string
+	}{
+		{OSInfoKernelName, &info.KernelName},
+		{OSInfoNodeName, &info.NodeName},
+		{OSInfoKernelRelease, &info.KernelRelease},
+		{OSInfoKernelVersion, &info.KernelVersion},
+		{OSInfoBuildDateTime, &info.BuildDateTime},
+		{OSInfoMachine, &info.Machine},
+		{OSInfoProcessor, &info.Processor},
+		{OSInfoPlatform, &info.Platform},
+		{OSInfoOS, &info.OS},
+	} {
+		out, err := c.OSInfo(ctx, field.format)
+		if isOSInfoNoAnswer(err) {
+			continue
+		}
+
+		if err != nil {
+			return OSInfo{}, fmt.Errorf("failed to read os info %q: %w", field.format, err)
+		}
+
+		*field.value = out
+	}
+
+	return info, nil
+}
+
+// isOSInfoNoAnswer returns true if device has no value for requested os info.
+//
+// Devices that report errors with top-level `rc` translate
+// "query yields no answer" into [RcNoEntry].
+func isOSInfoNoAnswer(err error) bool {
+	return errors.Is(err, &SMPError{Group: SMPGroupOS, Rc: RcOSQueryYieldsNoAnswer}) ||
+		errors.Is(err, &SMPError{Rc: RcNoEntry, Legacy: true})
+}
+
+// BootloaderInfo returns information about the bootloader.
+//
+// For MCUboot it will also query its mode, which defines
+// how images will be upgraded.
+func (c *SMPClient) BootloaderInfo(ctx context.Context) (BootloaderInfo, error) {
+	resp, err := sendRequest[BootloaderInfoResponse](ctx, c, "bootloader info", SMPOpReadRequest, SMPGroupOS, SMPCmdBootloaderInfo, BuildBootloaderInfoRequest(""))
+	if err != nil {
+		return BootloaderInfo{}, err
+	}
+
+	info := BootloaderInfo{Name: resp.Bootloader}
+	if info.Name != BootloaderMCUboot {
+		return info, nil
+	}
+
+	mode, err := sendRequest[BootloaderModeResponse](ctx, c, "bootloader mode", SMPOpReadRequest, SMPGroupOS, SMPCmdBootloaderInfo, BuildBootloaderInfoRequest("mode"))
+	if err != nil {
+		return info, err
+	}
+
+	info.Mode = mode.Mode
+	info.NoDowngrade = mode.NoDowngrade
+
+	return info, nil
+}
@@ -0,0 +1,155 @@
+package smp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestOSInfo(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		req, err := DecodeCBOR[OSInfoRequest](frame.Data)
+		if err != nil {
+			t.Errorf("decode request: %s", err.Error())
+		}
+
+		if frame.Header.CommandID != SMPCmdOSInfo || req.Format != "sr" {
+			t.Errorf("unexpected request: %+v, %+v", frame.Header, req)
+		}
+
+		return newTestResponse(frame, OSInfoResponse{Output: "Zephyr 3.7.0"}), nil
+	}
+
+	out, err := NewSMPClient(transport).OSInfo(ctx, OSInfoKernelName+OSInfoKernelRelease)
+	if err != nil {
+		t.Fatalf("os info: %s", err.Error())
+	}
+
+	if out != "Zephyr 3.7.0" {
+		t.Fatalf("wrong output: %q", out)
+	}
+}
+
+func TestReadOSInfo(t *testing.T) {
+	t.Parallel()
+
+	outputs := map[string]string{
+		OSInfoKernelName:    "Zephyr",
+		OSInfoNodeName:      "sensor",
+		OSInfoKernelRelease: "v3.7.0",
+		OSInfoKernelVersion: "v3.7.0-12-gabcdef",
+		OSInfoBuildDateTime: "Mon Oct 14 10:00:00 2024",
+		OSInfoMachine:       "arm",
+		OSInfoProcessor:     "cortex-m4",
+		OSInfoOS:            "Zephyr",
+	}
+
+	expected := OSInfo{
+		KernelName:    "Zephyr",
+		NodeName:      "sensor",
+		KernelRelease: "v3.7.0",
+		KernelVersion: "v3.7.0-12-gabcdef",
+		BuildDateTime: "Mon Oct 14 10:00:00 2024",
+		Machine:       "arm",
+		Processor:     "cortex-m4",
+		OS:            "Zephyr",
+	}
+
+	tests := []struct {
+		name     string
+		version  uint8
+		noAnswer map[string]any
+	}{
+		{
+			name:     "v2 group error",
+			version:  SMPVersion2,
+			noAnswer: map[string]any{"err": map[string]any{"group": SMPGroupOS, "rc": RcOSQueryYieldsNoAnswer}},
+		},
+		{
+			name:     "v2 top-level rc",
+			version:  SMPVersion2,
+			noAnswer: map[string]any{"rc": RcNoEntry},
+		},
+		{
+			name:     "legacy top-level rc",
+			version:  SMPVersionLegacy,
+			noAnswer: map[string]any{"rc": RcNoEntry},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			t.Cleanup(cancel)
+
+			transport := newDefaultTestTransport()
+			transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+				req, _ := DecodeCBOR[OSInfoRequest](frame.Data)
+
+				var resp SMPFrame
+				if out, ok := outputs[req.Format]; ok {
+					resp = newTestResponse(frame, OSInfoResponse{Output: out})
+				} else {
+					resp = newTestResponse(frame, tt.noAnswer)
+				}
+
+				resp.Header.Version = tt.version
+
+				return resp, nil
+			}
+
+			info, err := NewSMPClient(transport).ReadOSInfo(ctx)
+			if err != nil {
+				t.Fatalf("read os info: %s", err.Error())
+			}
+
+			if info != expected {
+				t.Fatalf("wrong os info: %+v", info)
+			}
+		})
+	}
+}
+
+func TestBootloaderInfo(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		req, _ := DecodeCBOR[BootloaderInfoRequest](frame.Data)
+
+		switch req.Query {
+		case "":
+			return newTestResponse(frame, map[string]any{"bootloader": "MCUboot"}), nil
+		case "mode":
+			return newTestResponse(frame, map[string]any{"mode": 5, "no-downgrade": true}), nil
+		default:
+			t.Errorf("unexpected query: %q", req.Query)
+
+			return newTestResponse(frame, map[string]any{"rc": RcNotSupported}), nil
+		}
+	}
+
+	info, err := NewSMPClient(transport).BootloaderInfo(ctx)
+	if err != nil {
+		t.Fatalf("bootloader info: %s", err.Error())
+	}
+
+	if info.Name != BootloaderMCUboot || info.Mode != MCUbootModeDirectXIPWithRevert || !info.NoDowngrade {
+		t.Fatalf("wrong info: %+v", info)
+	}
+
+	if !info.Mode.IsDirectXIP() || info.Mode.IsSwap() {
+		t.Fatalf("wrong mode classification for %s", info.Mode)
+	}
+}
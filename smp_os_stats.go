@@ -0,0 +1,25 @@
+package smp
+
+import (
+	"context"
+)
+
+// TaskStats returns statistics of all tasks (threads) on the device, by task name.
+func (c *SMPClient) TaskStats(ctx context.Context) (map[string]TaskStat, error) {
+	resp, err := sendRequest[TaskStatsResponse](ctx, c, "task stats", SMPOpReadRequest, SMPGroupOS, SMPCmdTaskStats, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Tasks, nil
+}
+
+// MemPoolStats returns statistics of all memory pools on the device, by pool name.
+func (c *SMPClient) MemPoolStats(ctx context.Context) (map[string]MemPoolStat, error) {
+	resp, err := sendRequest[MemPoolStatsResponse](ctx, c, "memory pool stats", SMPOpReadRequest, SMPGroupOS, SMPCmdMemPoolStats, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Pools, nil
+}
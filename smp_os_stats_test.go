@@ -0,0 +1,73 @@
+package smp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTaskStats(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		if frame.Header.CommandID != SMPCmdTaskStats {
+			t.Errorf("unexpected frame header: %+v", frame.Header)
+		}
+
+		return newTestResponse(frame, map[string]any{
+			"tasks": map[string]any{
+				"main": map[string]any{"prio": 0, "tid": 1, "state": 0, "stkuse": 96, "stksiz": 128, "cswcnt": 42, "runtime": 1000},
+				"idle": map[string]any{"prio": 15, "tid": 2, "state": 0, "stkuse": 10, "stksiz": 80},
+				// Cooperative thread.
+				"sysworkq": map[string]any{"prio": -1, "tid": 3, "state": 0, "stkuse": 200, "stksiz": 1024},
+			},
+		}), nil
+	}
+
+	tasks, err := NewSMPClient(transport).TaskStats(ctx)
+	if err != nil {
+		t.Fatalf("task stats: %s", err.Error())
+	}
+
+	main, ok := tasks["main"]
+	if !ok || len(tasks) != 3 {
+		t.Fatalf("wrong tasks: %+v", tasks)
+	}
+
+	if main.ContextSwitches != 42 || main.StackUsage() != 0.75 {
+		t.Fatalf("wrong main task stats: %+v", main)
+	}
+
+	if workq := tasks["sysworkq"]; workq.Priority != -1 {
+		t.Fatalf("wrong sysworkq priority: %d", workq.Priority)
+	}
+}
+
+func TestMemPoolStats(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		return newTestResponse(frame, map[string]any{
+			"mpools": map[string]any{
+				"net_buf": map[string]any{"blksiz": 128, "nblks": 16, "nfree": 10, "min": 2},
+			},
+		}), nil
+	}
+
+	pools, err := NewSMPClient(transport).MemPoolStats(ctx)
+	if err != nil {
+		t.Fatalf("mem pool stats: %s", err.Error())
+	}
+
+	if pool := pools["net_buf"]; pool.BlockCount != 16 || pool.Min != 2 {
+		t.Fatalf("wrong pool stats: %+v", pools)
+	}
+}
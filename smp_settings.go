@@ -0,0 +1,149 @@
+package smp
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+)
+
+// SettingsClient accesses device settings (Zephyr settings subsystem)
+// through Settings group.
+//
+// Settings values are stored on the device as raw bytes. [SettingsClient.Read]
+// and [SettingsClient.Write] convert them from and to Go values:
+//   - []byte is used as is;
+//   - string is stored as its bytes, without terminating zero;
+//   - fixed-size values (numbers, bools, arrays and structs of them)
+//     are stored in their binary form, in the byte order of the client.
+type SettingsClient struct {
+	client *SMPClient
+	order  binary.ByteOrder
+}
+
+// Settings returns settings sub-client, which stores values in little-endian
+// byte order, native for most of the devices.
+func (c *SMPClient) Settings() *SettingsClient {
+	return c.SettingsWithByteOrder(binary.LittleEndian)
+}
+
+// SettingsWithByteOrder returns settings sub-client,
+// which stores values in provided byte order.
+func (c *SMPClient) SettingsWithByteOrder(order binary.ByteOrder) *SettingsClient {
+	return &SettingsClient{client: c, order: order}
+}
+
+// ReadRaw reads value of the setting `name`.
+//
+// If `maxSize` is zero - device default maximum size is used.
+func (s *SettingsClient) ReadRaw(ctx context.Context, name string, maxSize uint32) ([]byte, error) {
+	req := SettingsReadRequest{Name: name, MaxSize: maxSize}
+
+	resp, err := sendRequest[SettingsReadResponse](ctx, s.client, "settings read", SMPOpReadRequest, SMPGroupSettings, SMPCmdSettingsReadWrite, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Val, nil
+}
+
+// Read reads value of the setting `name` into `value`, which must be a pointer.
+func (s *SettingsClient) Read(ctx context.Context, name string, value any) error {
+	var maxSize uint32
+
+	switch value.(type) {
+	case *[]byte, *string:
+	default:
+		size := binary.Size(value)
+		// Platform-sized types, like int, do not have fixed binary form.
+		if size < 0 {
+			return fmt.Errorf("unsupported type %T of setting %q", value, name)
+		}
+
+		maxSize = uint32(size)
+	}
+
+	raw, err := s.ReadRaw(ctx, name, maxSize)
+	if err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case *[]byte:
+		*v = raw
+	case *string:
+		*v = string(raw)
+	default:
+		if len(raw) != binary.Size(value) {
+			return fmt.Errorf("setting %q has %d bytes, want %d for %T", name, len(raw), binary.Size(value), value)
+		}
+
+		if err := binary.Read(bytes.NewReader(raw), s.order, value); err != nil {
+			return fmt.Errorf("failed to decode setting %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// WriteRaw writes value of the setting `name`.
+//
+// Value is not persisted until [SettingsClient.Save] is called.
+func (s *SettingsClient) WriteRaw(ctx context.Context, name string, val []byte) error {
+	req := SettingsWriteRequest{Name: name, Val: val}
+
+	_, err := sendRequest[struct{}](ctx, s.client, "settings write", SMPOpWriteRequest, SMPGroupSettings, SMPCmdSettingsReadWrite, req)
+
+	return err
+}
+
+// Write encodes `value` and writes it to the setting `name`.
+//
+// Value is not persisted until [SettingsClient.Save] is called.
+func (s *SettingsClient) Write(ctx context.Context, name string, value any) error {
+	var raw []byte
+
+	switch v := value.(type) {
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		var buf bytes.Buffer
+		if err := binary.Write(&buf, s.order, value); err != nil {
+			return fmt.Errorf("failed to encode setting %q: %w", name, err)
+		}
+
+		raw = buf.Bytes()
+	}
+
+	return s.WriteRaw(ctx, name, raw)
+}
+
+// Delete deletes the setting `name`.
+func (s *SettingsClient) Delete(ctx context.Context, name string) error {
+	_, err := sendRequest[struct{}](ctx, s.client, "settings delete", SMPOpWriteRequest, SMPGroupSettings, SMPCmdSettingsDelete, SettingsDeleteRequest{Name: name})
+
+	return err
+}
+
+// Commit applies written settings, so application starts to use them.
+func (s *SettingsClient) Commit(ctx context.Context) error {
+	_, err := sendRequest[struct{}](ctx, s.client, "settings commit", SMPOpWriteRequest, SMPGroupSettings, SMPCmdSettingsCommit, struct{}{})
+
+	return err
+}
+
+// Load loads settings from persistent storage.
+func (s *SettingsClient) Load(ctx context.Context) error {
+	_, err := sendRequest[struct{}](ctx, s.client, "settings load", SMPOpReadRequest, SMPGroupSettings, SMPCmdSettingsLoadSave, struct{}{})
+
+	return err
+}
+
+// Save saves settings to persistent storage.
+func (s *SettingsClient) Save(ctx context.Context) error {
+	_, err := sendRequest[struct{}](ctx, s.client, "settings save", SMPOpWriteRequest, SMPGroupSettings, SMPCmdSettingsLoadSave, struct{}{})
+
+	return err
+}
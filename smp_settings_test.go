@@ -0,0 +1,172 @@
+package smp
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newSettingsTestTransport returns transport that emulates settings group,
+// storing values in `stored` and persisting them to `saved` on save.
+func newSettingsTestTransport(t *testing.T, stored, saved map[string][]byte) *testTransport {
+	var mu sync.Mutex
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if frame.Header.GroupID != SMPGroupSettings {
+			t.Errorf("unexpected group: %d", frame.Header.GroupID)
+		}
+
+		notFound := map[string]any{
+			"err": map[string]any{"group": SMPGroupSettings, "rc": RcSettingsKeyNotFound},
+		}
+
+		switch {
+		case frame.Header.CommandID == SMPCmdSettingsReadWrite && frame.Header.Op == SMPOpReadRequest:
+			req, _ := DecodeCBOR[SettingsReadRequest](frame.Data)
+
+			val, ok := stored[req.Name]
+			if !ok {
+				return newTestResponse(frame, notFound), nil
+			}
+
+			if req.MaxSize != 0 && int(req.MaxSize) < len(val) {
+				val = val[:req.MaxSize]
+			}
+
+			return newTestResponse(frame, SettingsReadResponse{Val: val}), nil
+		case frame.Header.CommandID == SMPCmdSettingsReadWrite:
+			req, _ := DecodeCBOR[SettingsWriteRequest](frame.Data)
+			stored[req.Name] = req.Val
+		case frame.Header.CommandID == SMPCmdSettingsDelete:
+			req, _ := DecodeCBOR[SettingsDeleteRequest](frame.Data)
+			if _, ok := stored[req.Name]; !ok {
+				return newTestResponse(frame, notFound), nil
+			}
+
+			delete(stored, req.Name)
+		case frame.Header.CommandID == SMPCmdSettingsLoadSave && frame.Header.Op == SMPOpWriteRequest:
+			for name, val := range stored {
+				saved[name] = val
+			}
+		case frame.Header.CommandID == SMPCmdSettingsCommit, frame.Header.CommandID == SMPCmdSettingsLoadSave:
+		default:
+			t.Errorf("unexpected frame header: %+v", frame.Header)
+		}
+
+		return newTestResponse(frame, map[string]any{}), nil
+	}
+
+	return transport
+}
+
+func TestSettingsReadWrite(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	stored, saved := map[string][]byte{}, map[string][]byte{}
+	settings := NewSMPClient(newSettingsTestTransport(t, stored, saved)).Settings()
+
+	type params struct {
+		Channel uint8
+		PanID   uint16
+	}
+
+	networkKey := [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+	for name, value := range map[string]any{
+		"zb/key":    networkKey,
+		"zb/params": params{Channel: 15, PanID: 0x1a62},
+		"dev/name":  "sensor",
+		"dev/raw":   []byte{0xff, 0},
+		"dev/count": uint32(0x01020304),
+	} {
+		if err := settings.Write(ctx, name, value); err != nil {
+			t.Fatalf("write %q: %s", name, err.Error())
+		}
+	}
+
+	if !bytes.Equal(stored["dev/count"], []byte{4, 3, 2, 1}) || !bytes.Equal(stored["zb/params"], []byte{15, 0x62, 0x1a}) {
+		t.Fatalf("values are not stored in little-endian: %v", stored)
+	}
+
+	var key [16]byte
+	if err := settings.Read(ctx, "zb/key", &key); err != nil || key != networkKey {
+		t.Fatalf("read key: %v, %v", key, err)
+	}
+
+	var p params
+	if err := settings.Read(ctx, "zb/params", &p); err != nil || p.PanID != 0x1a62 {
+		t.Fatalf("read params: %+v, %v", p, err)
+	}
+
+	var name string
+	if err := settings.Read(ctx, "dev/name", &name); err != nil || name != "sensor" {
+		t.Fatalf("read name: %q, %v", name, err)
+	}
+
+	var raw []byte
+	if err := settings.Read(ctx, "dev/raw", &raw); err != nil || !bytes.Equal(raw, []byte{0xff, 0}) {
+		t.Fatalf("read raw: %v, %v", raw, err)
+	}
+
+	var platformSized int
+	if err := settings.Read(ctx, "dev/count", &platformSized); err == nil || !strings.Contains(err.Error(), "unsupported type *int") {
+		t.Fatalf("want unsupported type error, got %v", err)
+	}
+
+	var wrongSize uint64
+	if err := settings.Read(ctx, "dev/raw", &wrongSize); err == nil {
+		t.Fatalf("want error for value of wrong size")
+	}
+
+	if err := settings.Commit(ctx); err != nil {
+		t.Fatalf("commit: %s", err.Error())
+	}
+
+	if err := settings.Save(ctx); err != nil {
+		t.Fatalf("save: %s", err.Error())
+	}
+
+	if len(saved) != 5 {
+		t.Fatalf("want 5 saved settings, got %d", len(saved))
+	}
+}
+
+func TestSettingsDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	stored := map[string][]byte{"dev/count": {1, 0}}
+	settings := NewSMPClient(newSettingsTestTransport(t, stored, map[string][]byte{})).SettingsWithByteOrder(binary.BigEndian)
+
+	var count uint16
+	if err := settings.Read(ctx, "dev/count", &count); err != nil || count != 0x0100 {
+		t.Fatalf("read count: %d, %v", count, err)
+	}
+
+	if err := settings.Delete(ctx, "dev/count"); err != nil {
+		t.Fatalf("delete: %s", err.Error())
+	}
+
+	err := settings.Read(ctx, "dev/count", &count)
+	if !errors.Is(err, &SMPError{Group: SMPGroupSettings, Rc: RcSettingsKeyNotFound}) {
+		t.Fatalf("want key not found error, got %v", err)
+	}
+
+	if err := settings.Load(ctx); err != nil {
+		t.Fatalf("load: %s", err.Error())
+	}
+}
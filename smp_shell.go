@@ -0,0 +1,151 @@
+package smp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ShellResult is the result of shell command executed on the device.
+type ShellResult struct {
+	// Output of the command, as it would be printed to the shell.
+	Output string
+	// Ret is the return code of the command, zero on success.
+	Ret int
+}
+
+// ShellExec executes shell command on the device.
+//
+// First element of `argv` is the command itself, the rest are its arguments.
+// Command that ran but failed is not an error: its return code is set in result.
+func (c *SMPClient) ShellExec(ctx context.Context, argv []string) (ShellResult, error) {
+	if len(argv) == 0 {
+		return ShellResult{}, errors.New("command must be set")
+	}
+
+	resp, err := sendRequest[ShellExecResponse](ctx, c, "shell exec", SMPOpWriteRequest, SMPGroupShell, SMPCmdShellExec, ShellExecRequest{Argv: argv})
+	if err != nil {
+		return ShellResult{}, err
+	}
+
+	return ShellResult{Output: resp.O, Ret: resp.Ret}, nil
+}
+
+// ShellLines runs shell commands read from `in` line by line,
+// and writes their output to `out`, until `in` is exhausted.
+//
+// Lines are split into arguments on spaces, single or double quotes
+// can be used to pass arguments with spaces. Empty lines and lines
+// starting with `#` are skipped.
+//
+// Each command is executed with `cmdTimeout`. If command returns
+// non-zero code, or device rejects it - this is reported to `out`
+// and next command is executed. Other errors stop execution.
+func (c *SMPClient) ShellLines(ctx context.Context, in io.Reader, out io.Writer, cmdTimeout time.Duration) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		argv, err := splitShellLine(line)
+		if err != nil {
+			if _, err := fmt.Fprintf(out, "%s: %s\n", line, err.Error()); err != nil {
+				return fmt.Errorf("write output: %w", err)
+			}
+
+			continue
+		}
+
+		result, err := withTimeout(ctx, cmdTimeout, func(ctx context.Context) (ShellResult, error) {
+			return c.ShellExec(ctx, argv)
+		})
+
+		var smpErr *SMPError
+		switch {
+		case errors.As(err, &smpErr):
+			_, err = fmt.Fprintf(out, "%s: %s\n", line, smpErr.Error())
+		case err != nil:
+			return fmt.Errorf("execute %q: %w", line, err)
+		default:
+			_, err = io.WriteString(out, result.Output)
+			if err == nil && result.Output != "" && !strings.HasSuffix(result.Output, "\n") {
+				_, err = io.WriteString(out, "\n")
+			}
+
+			if err == nil && result.Ret != 0 {
+				_, err = fmt.Fprintf(out, "%s: command returned %d\n", line, result.Ret)
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read commands: %w", err)
+	}
+
+	return nil
+}
+
+// splitShellLine splits command line into arguments.
+//
+// Arguments are separated by spaces or tabs, and can be quoted
+// with single or double quotes. Backslash escapes next character
+// outside of single quotes.
+func splitShellLine(line string) ([]string, error) {
+	var argv []string
+	var arg strings.Builder
+
+	var quote rune
+	var inArg, escaped bool
+	for _, r := range line {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				argv = append(argv, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+
+	if escaped {
+		return nil, errors.New("trailing backslash")
+	}
+
+	if inArg {
+		argv = append(argv, arg.String())
+	}
+
+	return argv, nil
+}
@@ -0,0 +1,132 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newShellTestTransport returns transport that emulates device shell
+// with `echo`, `fail` and `kernel` commands.
+func newShellTestTransport(t *testing.T) *testTransport {
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		if frame.Header.Op != SMPOpWriteRequest || frame.Header.GroupID != SMPGroupShell || frame.Header.CommandID != SMPCmdShellExec {
+			t.Errorf("unexpected frame header: %+v", frame.Header)
+		}
+
+		req, err := DecodeCBOR[ShellExecRequest](frame.Data)
+		if err != nil {
+			t.Errorf("decode request: %s", err.Error())
+		}
+
+		switch req.Argv[0] {
+		case "echo":
+			return newTestResponse(frame, ShellExecResponse{O: strings.Join(req.Argv[1:], " ")}), nil
+		case "fail":
+			return newTestResponse(frame, ShellExecResponse{O: "failed\n", Ret: -22}), nil
+		case "kernel":
+			return newTestResponse(frame, map[string]any{
+				"err": map[string]any{"group": SMPGroupShell, "rc": RcShellCommandTooLong},
+			}), nil
+		}
+
+		return SMPFrame{}, errors.New("link lost")
+	}
+
+	return transport
+}
+
+func TestShellExec(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	client := NewSMPClient(newShellTestTransport(t))
+
+	result, err := client.ShellExec(ctx, []string{"echo", "hello", "world"})
+	if err != nil {
+		t.Fatalf("shell exec: %s", err.Error())
+	}
+
+	if result.Output != "hello world" || result.Ret != 0 {
+		t.Fatalf("wrong result: %+v", result)
+	}
+
+	result, err = client.ShellExec(ctx, []string{"fail"})
+	if err != nil {
+		t.Fatalf("shell exec: %s", err.Error())
+	}
+
+	if result.Ret != -22 {
+		t.Fatalf("want return code -22, got %d", result.Ret)
+	}
+
+	_, err = client.ShellExec(ctx, []string{"kernel"})
+	if !errors.Is(err, &SMPError{Group: SMPGroupShell, Rc: RcShellCommandTooLong}) {
+		t.Fatalf("want command too long error, got %v", err)
+	}
+}
+
+func TestShellLines(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	client := NewSMPClient(newShellTestTransport(t))
+
+	in := strings.NewReader("# comment\necho 'a  b' c\n\nfail\nkernel\necho \"unterminated\n")
+
+	var out strings.Builder
+	if err := client.ShellLines(ctx, in, &out, time.Second); err != nil {
+		t.Fatalf("shell lines: %s", err.Error())
+	}
+
+	expected := "a  b c\n" +
+		"failed\n" +
+		"fail: command returned -22\n" +
+		"kernel: smp error: group=9, rc=2 (command too long)\n" +
+		"echo \"unterminated: unterminated \" quote\n"
+	if out.String() != expected {
+		t.Fatalf("wrong output:\n%s\nwant:\n%s", out.String(), expected)
+	}
+
+	err := client.ShellLines(ctx, strings.NewReader("echo a\nreboot\necho b\n"), &out, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "link lost") {
+		t.Fatalf("want transport error, got %v", err)
+	}
+}
+
+func TestSplitShellLine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		line     string
+		expected []string
+	}{
+		{line: "kernel uptime", expected: []string{"kernel", "uptime"}},
+		{line: "  a \t b  ", expected: []string{"a", "b"}},
+		{line: `set "a b" 'c "d"'`, expected: []string{"set", "a b", `c "d"`}},
+		{line: `a\ b "" c`, expected: []string{"a b", "", "c"}},
+	}
+
+	for _, test := range tests {
+		argv, err := splitShellLine(test.line)
+		if err != nil {
+			t.Fatalf("split %q: %s", test.line, err.Error())
+		}
+
+		if !slices.Equal(argv, test.expected) {
+			t.Fatalf("split %q: want %q, got %q", test.line, test.expected, argv)
+		}
+	}
+
+	if _, err := splitShellLine(`a 'b`); err == nil {
+		t.Fatalf("want error for unterminated quote")
+	}
+}
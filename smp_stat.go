@@ -0,0 +1,99 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// StatsPollFn is called by [SMPClient.PollStats] after each read of statistics.
+//
+// `values` are current values of counters, and `deltas` - their change
+// since previous read. If function returns error - polling is stopped.
+type StatsPollFn func(values map[string]uint64, deltas map[string]uint64) error
+
+// ListStatGroups returns names of statistics groups available on the device.
+func (c *SMPClient) ListStatGroups(ctx context.Context) ([]string, error) {
+	resp, err := sendRequest[StatListResponse](ctx, c, "stat list", SMPOpReadRequest, SMPGroupStat, SMPCmdStatList, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.StatList, nil
+}
+
+// ReadStats returns values of counters in statistics group `group`, by name.
+func (c *SMPClient) ReadStats(ctx context.Context, group string) (map[string]uint64, error) {
+	resp, err := sendRequest[StatShowResponse](ctx, c, "stat show", SMPOpReadRequest, SMPGroupStat, SMPCmdStatShow, StatShowRequest{Name: group})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Fields == nil {
+		resp.Fields = map[string]uint64{}
+	}
+
+	return resp.Fields, nil
+}
+
+// PollStats reads statistics group `group` every `interval`
+// and calls `fn` with values of counters and their deltas.
+//
+// Each read is done with `timeout`. Deltas of the first read are nil.
+// If counter became smaller (i.e. device was restarted) - its delta
+// is its current value.
+//
+// Polling continues until context is done or `fn` returns error,
+// either of which is returned.
+func (c *SMPClient) PollStats(ctx context.Context, group string, interval time.Duration, timeout time.Duration, fn StatsPollFn) error {
+	if interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var prev map[string]uint64
+	for {
+		values, err := withTimeout(ctx, timeout, func(ctx context.Context) (map[string]uint64, error) {
+			return c.ReadStats(ctx, group)
+		})
+		if err != nil {
+			return err
+		}
+
+		var deltas map[string]uint64
+		if prev != nil {
+			deltas = statsDeltas(prev, values)
+		}
+
+		if err := fn(values, deltas); err != nil {
+			return err
+		}
+
+		prev = values
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// statsDeltas returns change of each counter in `values` since `prev`.
+func statsDeltas(prev, values map[string]uint64) map[string]uint64 {
+	deltas := make(map[string]uint64, len(values))
+	for name, value := range values {
+		if value < prev[name] {
+			// Counter was reset.
+			deltas[name] = value
+
+			continue
+		}
+
+		deltas[name] = value - prev[name]
+	}
+
+	return deltas
+}
@@ -0,0 +1,112 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newStatTestTransport returns transport that emulates statistics group
+// with `radio` group, counters of which are taken from `reads` one by one.
+func newStatTestTransport(t *testing.T, reads []map[string]uint64) *testTransport {
+	var read atomic.Int32
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		if frame.Header.Op != SMPOpReadRequest || frame.Header.GroupID != SMPGroupStat {
+			t.Errorf("unexpected frame header: %+v", frame.Header)
+		}
+
+		if frame.Header.CommandID == SMPCmdStatList {
+			return newTestResponse(frame, StatListResponse{StatList: []string{"radio", "smp"}}), nil
+		}
+
+		req, _ := DecodeCBOR[StatShowRequest](frame.Data)
+		if req.Name != "radio" {
+			return newTestResponse(frame, map[string]any{
+				"err": map[string]any{"group": SMPGroupStat, "rc": RcStatInvalidGroup},
+			}), nil
+		}
+
+		i := int(read.Add(1)) - 1
+
+		return newTestResponse(frame, StatShowResponse{Name: req.Name, Fields: reads[min(i, len(reads)-1)]}), nil
+	}
+
+	return transport
+}
+
+func TestReadStats(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	client := NewSMPClient(newStatTestTransport(t, []map[string]uint64{{"rx_err": 3, "tx_err": 1}}))
+
+	groups, err := client.ListStatGroups(ctx)
+	if err != nil {
+		t.Fatalf("list stat groups: %s", err.Error())
+	}
+
+	if !slices.Equal(groups, []string{"radio", "smp"}) {
+		t.Fatalf("wrong groups: %v", groups)
+	}
+
+	stats, err := client.ReadStats(ctx, "radio")
+	if err != nil {
+		t.Fatalf("read stats: %s", err.Error())
+	}
+
+	if !maps.Equal(stats, map[string]uint64{"rx_err": 3, "tx_err": 1}) {
+		t.Fatalf("wrong stats: %v", stats)
+	}
+
+	_, err = client.ReadStats(ctx, "unknown")
+	if !errors.Is(err, &SMPError{Group: SMPGroupStat, Rc: RcStatInvalidGroup}) {
+		t.Fatalf("want invalid group error, got %v", err)
+	}
+}
+
+func TestPollStats(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	client := NewSMPClient(newStatTestTransport(t, []map[string]uint64{
+		{"rx_err": 3, "tx_err": 1},
+		{"rx_err": 5, "tx_err": 1},
+		// Device was restarted.
+		{"rx_err": 2, "tx_err": 0},
+	}))
+
+	expected := []map[string]uint64{
+		nil,
+		{"rx_err": 2, "tx_err": 0},
+		{"rx_err": 2, "tx_err": 0},
+	}
+
+	errDone := errors.New("done")
+
+	var polls int
+	err := client.PollStats(ctx, "radio", time.Millisecond, time.Second, func(values, deltas map[string]uint64) error {
+		if !maps.Equal(deltas, expected[polls]) || (polls == 0) != (deltas == nil) {
+			t.Errorf("poll %d: want deltas %v, got %v", polls, expected[polls], deltas)
+		}
+
+		polls++
+		if polls == len(expected) {
+			return errDone
+		}
+
+		return nil
+	})
+	if !errors.Is(err, errDone) {
+		t.Fatalf("want error from callback, got %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+package smp
+
+import (
+	"context"
+)
+
+// EraseStorage erases storage partition of the device (Zephyr `storage_partition`),
+// which holds settings and file systems, effectively making a factory reset.
+//
+// Device should be reset after erase, as application may still use erased data.
+//
+// If erase fails - returned error will wrap [*SMPError]
+// of SMPGroupZephyrBasic with the reason, i.e. RcZephyrBasicFlashEraseFailed.
+func (c *SMPClient) EraseStorage(ctx context.Context) error {
+	_, err := sendRequest[struct{}](ctx, c, "erase storage", SMPOpWriteRequest, SMPGroupZephyrBasic, SMPCmdZephyrBasicEraseStorage, struct{}{})
+
+	return err
+}
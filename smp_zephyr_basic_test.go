@@ -0,0 +1,49 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEraseStorage(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	var fail bool
+
+	transport := newDefaultTestTransport()
+	transport.sendFn = func(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+		if frame.Header.Op != SMPOpWriteRequest || frame.Header.GroupID != SMPGroupZephyrBasic || frame.Header.CommandID != SMPCmdZephyrBasicEraseStorage {
+			t.Errorf("unexpected frame header: %+v", frame.Header)
+		}
+
+		if fail {
+			return newTestResponse(frame, map[string]any{
+				"err": map[string]any{"group": SMPGroupZephyrBasic, "rc": RcZephyrBasicFlashEraseFailed},
+			}), nil
+		}
+
+		return newTestResponse(frame, map[string]any{}), nil
+	}
+
+	client := NewSMPClient(transport)
+
+	if err := client.EraseStorage(ctx); err != nil {
+		t.Fatalf("erase storage: %s", err.Error())
+	}
+
+	fail = true
+
+	err := client.EraseStorage(ctx)
+	if !errors.Is(err, &SMPError{Group: SMPGroupZephyrBasic, Rc: RcZephyrBasicFlashEraseFailed}) {
+		t.Fatalf("want flash erase failed error, got %v", err)
+	}
+
+	if err.Error() != "erase storage command failed: smp error: group=63, rc=4 (flash erase failed)" {
+		t.Fatalf("wrong error message: %s", err.Error())
+	}
+}
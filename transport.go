@@ -19,3 +19,12 @@ type Transport interface {
 	Send(ctx context.Context, frame SMPFrame) (SMPFrame, error)
 	Close() error
 }
+
+// MTUTransport can be implemented by transports that
+// limit the size of a single frame they can send.
+type MTUTransport interface {
+	Transport
+	// MTU returns maximum size of the frame, including SMP header.
+	// Zero is returned if it is not known.
+	MTU() int
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"sync"
 	"time"
 
 	"tinygo.org/x/bluetooth"
@@ -13,7 +12,10 @@ import (
 
 var characteristicSMPUUID, _ = bluetooth.ParseUUID("da2e7828-fbce-4e01-ae9e-261174997c48")
 
-var _ Transport = (*BLETransport)(nil)
+// bleATTHeaderSize is the size of ATT write header (opcode and handle).
+const bleATTHeaderSize = 3
+
+var _ MTUTransport = (*BLETransport)(nil)
 
 type BLETransport struct {
 	cfg BLETransportConfig
@@ -25,8 +27,7 @@ type BLETransport struct {
 
 	rcv chan SMPFrame
 
-	cbs   map[uint8]func(frame SMPFrame)
-	cbsMu sync.Mutex
+	mux *Mux
 }
 
 type BLETransportConfig struct {
@@ -43,7 +44,7 @@ func NewBLETransport(cfg BLETransportConfig) (*BLETransport, error) {
 		adapter: bluetooth.DefaultAdapter,
 		cfg:     cfg,
 		rcv:     make(chan SMPFrame, 16),
-		cbs:     make(map[uint8]func(frame SMPFrame)),
+		mux:     NewMux(),
 	}, nil
 }
 
@@ -124,12 +125,25 @@ func (b *BLETransport) Send(ctx context.Context, frame SMPFrame) (SMPFrame, erro
 		return SMPFrame{}, fmt.Errorf("convert frame to bytes: %w", err)
 	}
 
-	_, err = b.smpCharacteristic.WriteWithoutResponse(data)
-	if err != nil {
-		return SMPFrame{}, fmt.Errorf("write data: %w", err)
+	return b.mux.RoundTrip(ctx, frame.Header.SequenceNum, func() error {
+		if _, err := b.smpCharacteristic.WriteWithoutResponse(data); err != nil {
+			return fmt.Errorf("write data: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// MTU implements MTUTransport.
+func (b *BLETransport) MTU() int {
+	mtu, err := b.smpCharacteristic.GetMTU()
+	if err != nil || mtu <= bleATTHeaderSize {
+		return 0
 	}
 
-	return b.waitForResp(ctx, frame.Header.SequenceNum)
+	// Frame is sent as single ATT write,
+	// which has its own header.
+	return int(mtu) - bleATTHeaderSize
 }
 
 func (b *BLETransport) setSMPCharacteristic() error {
@@ -167,15 +181,7 @@ func (b *BLETransport) receiveCallback() error {
 			return
 		}
 
-		b.cbsMu.Lock()
-		defer b.cbsMu.Unlock()
-
-		seq := smp.Header.SequenceNum
-		if cb := b.cbs[seq]; cb != nil {
-			delete(b.cbs, seq)
-
-			cb(smp)
-		}
+		b.mux.Dispatch(smp)
 	})
 	if err != nil {
 		return fmt.Errorf("enable characteristic notifications: %w", err)
@@ -183,36 +189,3 @@ func (b *BLETransport) receiveCallback() error {
 
 	return nil
 }
-
-func (b *BLETransport) waitForResp(ctx context.Context, seq uint8) (SMPFrame, error) {
-	if _, ok := ctx.Deadline(); !ok {
-		return SMPFrame{}, errors.New("context must have deadline set for wait")
-	}
-
-	resp := make(chan SMPFrame)
-
-	b.cbsMu.Lock()
-	b.cbs[seq] = func(frame SMPFrame) {
-		resp <- frame
-	}
-	b.cbsMu.Unlock()
-
-	defer func() {
-		b.cbsMu.Lock()
-		defer b.cbsMu.Unlock()
-
-		delete(b.cbs, seq)
-	}()
-
-	select {
-	case <-ctx.Done():
-		err := ctx.Err()
-		if errors.Is(err, context.DeadlineExceeded) {
-			return SMPFrame{}, ErrWaitTimeout
-		}
-
-		return SMPFrame{}, ctx.Err()
-	case frame := <-resp:
-		return frame, nil
-	}
-}
@@ -0,0 +1,260 @@
+package smp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+
+	"go.bug.st/serial"
+)
+
+// Serial framing markers, as defined by MCUmgr "SMP over console".
+//
+// https://docs.zephyrproject.org/latest/services/device_mgmt/smp_transport.html#uart-serial-and-console
+var (
+	serialFrameStart        = []byte{0x06, 0x09}
+	serialFrameContinuation = []byte{0x04, 0x14}
+)
+
+const (
+	// serialMaxLineLen is the maximum length of a single line,
+	// including 2-byte marker and trailing newline.
+	serialMaxLineLen = 127
+	// serialMaxLineData is the number of raw bytes that fit into one line.
+	// Each line is base64 encoded on its own, so it must be a multiple of 3.
+	serialMaxLineData = (serialMaxLineLen - 3) / 4 * 3
+
+	DefaultSerialBaudRate = 115200
+)
+
+var _ Transport = (*SerialTransport)(nil)
+
+// SerialTransport sends SMP frames over serial port (UART, USB-CDC)
+// with MCUmgr console framing.
+type SerialTransport struct {
+	cfg SerialTransportConfig
+
+	port    io.ReadWriteCloser
+	writeMu sync.Mutex
+
+	mux *Mux
+}
+
+type SerialTransportConfig struct {
+	// Port is the name of the serial device, i.e. `/dev/ttyACM0` or `COM3`.
+	Port string
+	// BaudRate of the port. If not set - DefaultSerialBaudRate will be used.
+	BaudRate int
+}
+
+func NewSerialTransport(cfg SerialTransportConfig) (*SerialTransport, error) {
+	if cfg.Port == "" {
+		return nil, errors.New("serial port must be set")
+	}
+
+	if cfg.BaudRate == 0 {
+		cfg.BaudRate = DefaultSerialBaudRate
+	}
+
+	return &SerialTransport{
+		cfg: cfg,
+		mux: NewMux(),
+	}, nil
+}
+
+// Connect implements Transport.
+func (s *SerialTransport) Connect(ctx context.Context) error {
+	port, err := serial.Open(s.cfg.Port, &serial.Mode{
+		BaudRate: s.cfg.BaudRate,
+		DataBits: 8,
+		Parity:   serial.NoParity,
+		StopBits: serial.OneStopBit,
+	})
+	if err != nil {
+		return fmt.Errorf("open serial port: %w", err)
+	}
+
+	s.port = port
+
+	go s.readLoop()
+
+	return nil
+}
+
+// Close implements Transport.
+func (s *SerialTransport) Close() error {
+	if s.port == nil {
+		return nil
+	}
+
+	if err := s.port.Close(); err != nil {
+		return fmt.Errorf("close serial port: %w", err)
+	}
+
+	return nil
+}
+
+// Send implements Transport.
+func (s *SerialTransport) Send(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+	data, err := SMPFrameToFrame(frame)
+	if err != nil {
+		return SMPFrame{}, fmt.Errorf("convert frame to bytes: %w", err)
+	}
+
+	return s.mux.RoundTrip(ctx, frame.Header.SequenceNum, func() error {
+		s.writeMu.Lock()
+		defer s.writeMu.Unlock()
+
+		if _, err := s.port.Write(encodeSerialFrame(data)); err != nil {
+			return fmt.Errorf("write data: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *SerialTransport) readLoop() {
+	rd := bufio.NewReader(s.port)
+
+	var dec serialFrameDecoder
+	for {
+		line, err := rd.ReadBytes('\n')
+		if err != nil {
+			// Port was closed or device was disconnected.
+			slog.Debug("serial read stopped", "err", err.Error())
+
+			return
+		}
+
+		pkt, err := dec.feed(line)
+		if err != nil {
+			slog.Error("decode serial frame", "err", err.Error())
+
+			continue
+		}
+
+		if pkt == nil {
+			continue
+		}
+
+		smp, err := FrameToSMPFrame(pkt)
+		if err != nil {
+			slog.Error("decode received data", "err", err.Error())
+
+			continue
+		}
+
+		s.mux.Dispatch(smp)
+	}
+}
+
+// encodeSerialFrame wraps raw SMP packet into console frame.
+//
+// Packet is prefixed with total length and suffixed with CRC16,
+// then it is split into base64-encoded lines. First line starts with
+// start marker, all following ones - with continuation marker.
+func encodeSerialFrame(pkt []byte) []byte {
+	body := make([]byte, 2, 2+len(pkt)+2)
+	binary.BigEndian.PutUint16(body, uint16(len(pkt)+2))
+	body = append(body, pkt...)
+	body = binary.BigEndian.AppendUint16(body, crc16CCITT(pkt))
+
+	var out bytes.Buffer
+	for i := 0; i < len(body); i += serialMaxLineData {
+		if i == 0 {
+			out.Write(serialFrameStart)
+		} else {
+			out.Write(serialFrameContinuation)
+		}
+
+		chunk := body[i:min(i+serialMaxLineData, len(body))]
+		out.WriteString(base64.StdEncoding.EncodeToString(chunk))
+		out.WriteByte('\n')
+	}
+
+	return out.Bytes()
+}
+
+// serialFrameDecoder re-assembles SMP packets from console lines.
+//
+// Lines that do not start with frame markers are ignored,
+// as they are most likely regular console output.
+type serialFrameDecoder struct {
+	buf     []byte
+	started bool
+}
+
+// feed adds one received line to the decoder.
+//
+// It will return non-nil packet once it was fully received.
+func (d *serialFrameDecoder) feed(line []byte) ([]byte, error) {
+	line = bytes.TrimRight(line, "\r\n")
+
+	switch {
+	case bytes.HasPrefix(line, serialFrameStart):
+		d.buf = d.buf[:0]
+		d.started = true
+	case bytes.HasPrefix(line, serialFrameContinuation):
+		if !d.started {
+			return nil, errors.New("continuation without start frame")
+		}
+	default:
+		return nil, nil
+	}
+
+	decoded, err := base64.StdEncoding.AppendDecode(d.buf, line[2:])
+	if err != nil {
+		d.started = false
+
+		return nil, fmt.Errorf("decode base64: %w", err)
+	}
+
+	d.buf = decoded
+
+	if len(d.buf) < 2 {
+		return nil, nil
+	}
+
+	expectedLen := int(binary.BigEndian.Uint16(d.buf))
+	if len(d.buf)-2 < expectedLen {
+		return nil, nil
+	}
+
+	d.started = false
+
+	if len(d.buf)-2 > expectedLen || expectedLen < 2 {
+		return nil, fmt.Errorf("frame length mismatch: header: %d, actual: %d", expectedLen, len(d.buf)-2)
+	}
+
+	pkt, crc := d.buf[2:len(d.buf)-2], binary.BigEndian.Uint16(d.buf[len(d.buf)-2:])
+	if calculated := crc16CCITT(pkt); calculated != crc {
+		return nil, fmt.Errorf("crc mismatch: received: %#04x, calculated: %#04x", crc, calculated)
+	}
+
+	return bytes.Clone(pkt), nil
+}
+
+// crc16CCITT calculates CRC16-CCITT (XMODEM variant) used by MCUmgr serial framing:
+// polynomial 0x1021, initial value 0x0000.
+func crc16CCITT(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
@@ -0,0 +1,182 @@
+package smp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/creack/pty"
+)
+
+func TestCRC16CCITT(t *testing.T) {
+	t.Parallel()
+
+	// Standard check value for CRC-16/XMODEM.
+	if crc := crc16CCITT([]byte("123456789")); crc != 0x31c3 {
+		t.Fatalf("wrong crc: %#04x, want: %#04x", crc, 0x31c3)
+	}
+}
+
+func TestSerialFrameRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int{8, serialMaxLineData - 4, serialMaxLineData, 500, 2048} {
+		pkt := make([]byte, size)
+		if _, err := rand.Read(pkt); err != nil {
+			t.Fatalf("generate data: %s", err.Error())
+		}
+
+		encoded := encodeSerialFrame(pkt)
+
+		var dec serialFrameDecoder
+		var decoded []byte
+
+		lines := bytes.SplitAfter(encoded, []byte{'\n'})
+		// Mix in some console output, it must be ignored.
+		lines = append([][]byte{[]byte("uart:~$ \r\n")}, lines...)
+
+		for i, line := range lines {
+			if len(line) == 0 {
+				continue
+			}
+
+			if len(line) > serialMaxLineLen {
+				t.Fatalf("size %d: line %d is too long: %d", size, i, len(line))
+			}
+
+			res, err := dec.feed(line)
+			if err != nil {
+				t.Fatalf("size %d: feed line %d: %s", size, i, err.Error())
+			}
+
+			if res != nil {
+				decoded = res
+			}
+		}
+
+		if !bytes.Equal(pkt, decoded) {
+			t.Fatalf("size %d: decoded packet differs", size)
+		}
+	}
+}
+
+func TestSerialFrameBadCRC(t *testing.T) {
+	t.Parallel()
+
+	encoded := encodeSerialFrame([]byte{0, 1, 2, 3, 4, 5, 6, 7})
+
+	// Re-encode with broken crc.
+	line := bytes.TrimSuffix(encoded, []byte{'\n'})
+	raw, err := base64.StdEncoding.DecodeString(string(line[2:]))
+	if err != nil {
+		t.Fatalf("decode line: %s", err.Error())
+	}
+	raw[len(raw)-1] ^= 0xff
+
+	broken := append(bytes.Clone(serialFrameStart), base64.StdEncoding.EncodeToString(raw)...)
+	var dec serialFrameDecoder
+	if _, err := dec.feed(broken); err == nil {
+		t.Fatalf("expected crc error")
+	}
+}
+
+func TestSerialTransportPTY(t *testing.T) {
+	t.Parallel()
+
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		t.Skipf("pseudo-terminal is not available: %s", err.Error())
+	}
+	t.Cleanup(func() {
+		ptmx.Close()
+		tty.Close()
+	})
+
+	go emulateSerialDevice(t, ptmx)
+
+	transport, err := NewSerialTransport(SerialTransportConfig{
+		Port: tty.Name(),
+	})
+	if err != nil {
+		t.Fatalf("create serial transport: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	if err := transport.Connect(ctx); err != nil {
+		t.Fatalf("connect: %s", err.Error())
+	}
+	t.Cleanup(func() { transport.Close() })
+
+	client := NewSMPClient(transport)
+
+	if err := client.ResetDevice(ctx, false); err != nil {
+		t.Fatalf("reset: %s", err.Error())
+	}
+
+	img := make([]byte, 1024)
+	if _, err := rand.Read(img); err != nil {
+		t.Fatalf("generate data: %s", err.Error())
+	}
+
+	var uploaded int
+	err = client.UploadImageWithWindows(ctx, 1, img, 128, func(frame FirmwareUploadRequest) {
+		uploaded += len(frame.Data)
+	})
+	if err != nil {
+		t.Fatalf("upload: %s", err.Error())
+	}
+
+	if uploaded != len(img) {
+		t.Fatalf("uploaded size different: %d != %d", uploaded, len(img))
+	}
+}
+
+// emulateSerialDevice responds to every received request
+// with empty successful response.
+func emulateSerialDevice(t *testing.T, port *os.File) {
+	rd := bufio.NewReader(port)
+
+	var dec serialFrameDecoder
+	for {
+		line, err := rd.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+
+		pkt, err := dec.feed(line)
+		if err != nil {
+			t.Errorf("device: decode frame: %s", err.Error())
+
+			return
+		}
+
+		if pkt == nil {
+			continue
+		}
+
+		req, err := FrameToSMPFrame(pkt)
+		if err != nil {
+			t.Errorf("device: decode smp frame: %s", err.Error())
+
+			return
+		}
+
+		data, _ := EncodeCBOR(map[string]any{})
+		resp := req
+		resp.Header.Op = req.Header.Op + 1
+		resp.Header.DataLength = uint16(len(data))
+		resp.Data = data
+
+		raw, _ := SMPFrameToFrame(resp)
+		if _, err := port.Write(encodeSerialFrame(raw)); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package smp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+)
+
+// DefaultUDPPort is the port Zephyr MCUmgr UDP transport listens on.
+const DefaultUDPPort = "1337"
+
+// udpMaxDatagram is the maximum size of UDP payload.
+const udpMaxDatagram = 65535
+
+// Delays between reads after read error, so persistent errors
+// (i.e. connection refused) do not make read loop spin.
+const (
+	udpReadErrorMinDelay = 10 * time.Millisecond
+	udpReadErrorMaxDelay = time.Second
+)
+
+var _ Transport = (*UDPTransport)(nil)
+
+// UDPTransport sends SMP frames over UDP, one frame per datagram.
+type UDPTransport struct {
+	cfg UDPTransportConfig
+
+	conn net.Conn
+
+	mux *Mux
+}
+
+type UDPTransportConfig struct {
+	// Address of the device. It can be IPv4 or IPv6 address or a host name,
+	// with or without port. If port is not set - DefaultUDPPort will be used.
+	//
+	// Examples: `192.0.2.1`, `[2001:db8::1]:1337`, `device.local`.
+	Address string
+}
+
+func NewUDPTransport(cfg UDPTransportConfig) (*UDPTransport, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("udp address must be set")
+	}
+
+	if _, _, err := net.SplitHostPort(cfg.Address); err != nil {
+		// Address without port, IPv6 addresses may be passed with or without brackets.
+		host := cfg.Address
+		if len(host) > 1 && host[0] == '[' && host[len(host)-1] == ']' {
+			host = host[1 : len(host)-1]
+		}
+
+		cfg.Address = net.JoinHostPort(host, DefaultUDPPort)
+	}
+
+	return &UDPTransport{
+		cfg: cfg,
+		mux: NewMux(),
+	}, nil
+}
+
+// Connect implements Transport.
+//
+// As UDP is connectionless this only resolves the address
+// and starts to listen for responses.
+func (u *UDPTransport) Connect(ctx context.Context) error {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "udp", u.cfg.Address)
+	if err != nil {
+		return fmt.Errorf("dial udp: %w", err)
+	}
+
+	u.conn = conn
+
+	go u.readLoop()
+
+	return nil
+}
+
+// Close implements Transport.
+func (u *UDPTransport) Close() error {
+	if u.conn == nil {
+		return nil
+	}
+
+	if err := u.conn.Close(); err != nil {
+		return fmt.Errorf("close udp connection: %w", err)
+	}
+
+	return nil
+}
+
+// Send implements Transport.
+func (u *UDPTransport) Send(ctx context.Context, frame SMPFrame) (SMPFrame, error) {
+	data, err := SMPFrameToFrame(frame)
+	if err != nil {
+		return SMPFrame{}, fmt.Errorf("convert frame to bytes: %w", err)
+	}
+
+	return u.mux.RoundTrip(ctx, frame.Header.SequenceNum, func() error {
+		// Writes of separate datagrams are safe to do concurrently.
+		if _, err := u.conn.Write(data); err != nil {
+			return fmt.Errorf("write data: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (u *UDPTransport) readLoop() {
+	buf := make([]byte, udpMaxDatagram)
+
+	var errDelay time.Duration
+	for {
+		n, err := u.conn.Read(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			// Errors like ICMP port unreachable are reported on read,
+			// they should not stop the transport.
+			slog.Warn("read udp datagram", "err", err.Error())
+
+			errDelay = min(max(2*errDelay, udpReadErrorMinDelay), udpReadErrorMaxDelay)
+			time.Sleep(errDelay)
+
+			continue
+		}
+
+		errDelay = 0
+
+		smp, err := FrameToSMPFrame(buf[:n])
+		if err != nil {
+			slog.Error("decode received data", "err", err.Error())
+
+			continue
+		}
+
+		// Frame data must not reference shared read buffer.
+		smp.Data = append([]byte(nil), smp.Data...)
+
+		u.mux.Dispatch(smp)
+	}
+}
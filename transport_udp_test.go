@@ -0,0 +1,122 @@
+package smp
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewUDPTransportAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{address: "192.0.2.1", want: "192.0.2.1:1337"},
+		{address: "192.0.2.1:2000", want: "192.0.2.1:2000"},
+		{address: "2001:db8::1", want: "[2001:db8::1]:1337"},
+		{address: "[2001:db8::1]", want: "[2001:db8::1]:1337"},
+		{address: "[2001:db8::1]:2000", want: "[2001:db8::1]:2000"},
+		{address: "device.local", want: "device.local:1337"},
+	}
+
+	for _, tt := range tests {
+		transport, err := NewUDPTransport(UDPTransportConfig{Address: tt.address})
+		if err != nil {
+			t.Fatalf("%s: create transport: %s", tt.address, err.Error())
+		}
+
+		if transport.cfg.Address != tt.want {
+			t.Fatalf("%s: wrong address: %s, want: %s", tt.address, transport.cfg.Address, tt.want)
+		}
+	}
+}
+
+func TestUDPTransportLoopback(t *testing.T) {
+	t.Parallel()
+
+	for _, network := range []string{"127.0.0.1", "::1"} {
+		t.Run(network, func(t *testing.T) {
+			t.Parallel()
+
+			listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP(network)})
+			if err != nil {
+				t.Skipf("listen on %s: %s", network, err.Error())
+			}
+			t.Cleanup(func() { listener.Close() })
+
+			go emulateUDPDevice(listener)
+
+			transport, err := NewUDPTransport(UDPTransportConfig{
+				Address: listener.LocalAddr().String(),
+			})
+			if err != nil {
+				t.Fatalf("create udp transport: %s", err.Error())
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			t.Cleanup(cancel)
+
+			if err := transport.Connect(ctx); err != nil {
+				t.Fatalf("connect: %s", err.Error())
+			}
+			t.Cleanup(func() { transport.Close() })
+
+			client := NewSMPClient(transport)
+
+			// Multiple requests in-flight at the same time
+			// must all get their own responses.
+			var wg sync.WaitGroup
+			for i := range 10 {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+
+					data := []byte{byte(i)}
+					frame := client.CreateFrame(SMPOpReadRequest, SMPGroupOS, SMPCmdEcho, data)
+
+					resp, err := transport.Send(ctx, frame)
+					if err != nil {
+						t.Errorf("send %d: %s", i, err.Error())
+
+						return
+					}
+
+					if resp.Header.SequenceNum != frame.Header.SequenceNum || resp.Data[0] != data[0] {
+						t.Errorf("send %d: wrong response: %+v", i, resp)
+					}
+				}(i)
+			}
+
+			wg.Wait()
+		})
+	}
+}
+
+// emulateUDPDevice responds to each datagram with the same frame,
+// changing only operation to response.
+func emulateUDPDevice(conn *net.UDPConn) {
+	buf := make([]byte, udpMaxDatagram)
+
+	for {
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+
+		req, err := FrameToSMPFrame(buf[:n])
+		if err != nil {
+			continue
+		}
+
+		req.Header.Op++
+
+		resp, _ := SMPFrameToFrame(req)
+		if _, err := conn.WriteToUDP(resp, addr); err != nil {
+			return
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package smp
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
 
 // ResetRequest represents the CBOR data for a reset command
 type ResetRequest struct {
@@ -15,9 +20,140 @@ type ResetResponse struct {
 }
 
 // ErrorResponse represents the error response in SMP v2
+//
+// Client methods return it as [*SMPError].
 type ErrorResponse struct {
-	Group uint8 `cbor:"group"`
-	Rc    uint8 `cbor:"rc"`
+	Group uint16 `cbor:"group"`
+	Rc    int    `cbor:"rc"`
+}
+
+// EchoRequest represents the CBOR data for echo command
+type EchoRequest struct {
+	D string `cbor:"d"`
+}
+
+// EchoResponse represents the CBOR data for echo response
+type EchoResponse struct {
+	R   string         `cbor:"r"`
+	Err *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// ConsoleEchoRequest represents the CBOR data for console echo control command
+type ConsoleEchoRequest struct {
+	// Echo is 1 to enable echo and 0 to disable it.
+	// Devices expect an integer here, not a boolean.
+	Echo int `cbor:"echo"`
+}
+
+// ConsoleEchoResponse represents the CBOR data for console echo control response
+type ConsoleEchoResponse struct {
+	Err *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// MCUMgrParamsResponse represents the CBOR data for MCUmgr parameters response
+type MCUMgrParamsResponse struct {
+	// BufSize is the size of single SMP buffer on the device,
+	// SMP frame with header must fit in it.
+	BufSize uint32 `cbor:"buf_size"`
+	// BufCount is the number of SMP buffers on the device.
+	BufCount uint32         `cbor:"buf_count"`
+	Err      *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// OSInfoRequest represents the CBOR data for OS info request
+type OSInfoRequest struct {
+	// Format is a string of OSInfo* characters.
+	// If empty - device will return kernel name.
+	Format string `cbor:"format,omitempty"`
+}
+
+// OSInfoResponse represents the CBOR data for OS info response
+type OSInfoResponse struct {
+	Output string         `cbor:"output"`
+	Err    *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// BootloaderInfoRequest represents the CBOR data for bootloader info request
+type BootloaderInfoRequest struct {
+	// Query is the bootloader-specific parameter to query.
+	// If empty - device will return bootloader name.
+	Query string `cbor:"query,omitempty"`
+}
+
+// BootloaderInfoResponse represents the CBOR data for bootloader info response
+// without query
+type BootloaderInfoResponse struct {
+	Bootloader string         `cbor:"bootloader"`
+	Err        *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// BootloaderModeResponse represents the CBOR data for MCUboot `mode` query response
+type BootloaderModeResponse struct {
+	Mode        MCUbootMode    `cbor:"mode"`
+	NoDowngrade bool           `cbor:"no-downgrade,omitempty"`
+	Err         *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// DateTimeRequest represents the CBOR data for date-time write request
+type DateTimeRequest struct {
+	DateTime string `cbor:"datetime"`
+}
+
+// DateTimeResponse represents the CBOR data for date-time read response
+type DateTimeResponse struct {
+	DateTime string         `cbor:"datetime"`
+	Err      *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// TaskStatsResponse represents the CBOR data for task statistics response
+type TaskStatsResponse struct {
+	// Tasks by their names.
+	Tasks map[string]TaskStat `cbor:"tasks"`
+	Err   *ErrorResponse      `cbor:"err,omitempty"` // Optional error response
+}
+
+// TaskStat represents statistics of a single task (thread)
+type TaskStat struct {
+	// Priority of the task, cooperative threads have negative priority.
+	Priority int32  `cbor:"prio"`
+	TID      uint32 `cbor:"tid"`
+	// State is OS-specific state of the task.
+	State uint32 `cbor:"state"`
+	// StackUse and StackSize are reported in units defined by the device,
+	// on Zephyr they are 4-byte words by default.
+	StackUse        uint32 `cbor:"stkuse,omitempty"`
+	StackSize       uint32 `cbor:"stksiz,omitempty"`
+	ContextSwitches uint32 `cbor:"cswcnt,omitempty"`
+	Runtime         uint32 `cbor:"runtime,omitempty"`
+	LastCheckin     uint32 `cbor:"last_checkin,omitempty"`
+	NextCheckin     uint32 `cbor:"next_checkin,omitempty"`
+}
+
+// StackUsage returns used fraction of the stack, from 0 to 1.
+//
+// Zero is returned if device does not report stack size.
+func (t TaskStat) StackUsage() float64 {
+	if t.StackSize == 0 {
+		return 0
+	}
+
+	return float64(t.StackUse) / float64(t.StackSize)
+}
+
+// MemPoolStatsResponse represents the CBOR data for memory pool statistics response
+type MemPoolStatsResponse struct {
+	// Pools by their names.
+	Pools map[string]MemPoolStat `cbor:"mpools"`
+	Err   *ErrorResponse         `cbor:"err,omitempty"` // Optional error response
+}
+
+// MemPoolStat represents statistics of a single memory pool
+type MemPoolStat struct {
+	BlockSize  uint32 `cbor:"blksiz"`
+	BlockCount uint32 `cbor:"nblks"`
+	Free       uint32 `cbor:"nfree"`
+	// Min is the lowest number of free blocks ever observed.
+	Min uint32 `cbor:"min"`
 }
 
 // FirmwareUploadRequest represents the CBOR data for firmware upload
@@ -32,9 +168,9 @@ type FirmwareUploadRequest struct {
 
 // FirmwareUploadResponse represents the CBOR data for firmware upload response
 type FirmwareUploadResponse struct {
-	Off   uint32        `cbor:"off,omitempty"`
-	Match bool          `cbor:"match,omitempty"`
-	Err   ErrorResponse `cbor:"err,omitempty"` // Optional error response
+	Off   uint32         `cbor:"off,omitempty"`
+	Match bool           `cbor:"match,omitempty"`
+	Err   *ErrorResponse `cbor:"err,omitempty"` // Optional error response
 }
 
 // ImageStateRequest represents the CBOR data for image state request
@@ -42,6 +178,14 @@ type ImageStateRequest struct {
 	// Empty request
 }
 
+// ImageStateSetRequest represents the CBOR data for image state write request
+type ImageStateSetRequest struct {
+	// Hash of the image to mark as pending.
+	// Can be omitted when confirming currently running image.
+	Hash    []byte `cbor:"hash,omitempty"`
+	Confirm bool   `cbor:"confirm"`
+}
+
 // ImageStateResponse represents the CBOR data for image state response
 type ImageStateResponse struct {
 	Images      []ImageInfo    `cbor:"images"`
@@ -62,6 +206,18 @@ type ImageInfo struct {
 	Permanent *bool   `cbor:"permanent,omitempty"`
 }
 
+// ImageNumber returns number of the image.
+//
+// Single-image devices may not report image number,
+// in this case it is zero.
+func (i ImageInfo) ImageNumber() uint32 {
+	if i.Image == nil {
+		return 0
+	}
+
+	return *i.Image
+}
+
 // ImageEraseRequest represents the CBOR data for image erase request
 type ImageEraseRequest struct {
 	Slot *uint32 `cbor:"slot,omitempty"`
@@ -72,11 +228,320 @@ type ImageEraseResponse struct {
 	Err *ErrorResponse `cbor:"err,omitempty"` // Optional error response
 }
 
+// StatShowRequest represents the CBOR data for statistics group read request
+type StatShowRequest struct {
+	Name string `cbor:"name"`
+}
+
+// StatShowResponse represents the CBOR data for statistics group read response
+type StatShowResponse struct {
+	Name   string            `cbor:"name"`
+	Fields map[string]uint64 `cbor:"fields"`
+	Err    *ErrorResponse    `cbor:"err,omitempty"` // Optional error response
+}
+
+// StatListResponse represents the CBOR data for statistics groups list response
+type StatListResponse struct {
+	StatList []string       `cbor:"stat_list"`
+	Err      *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// LogShowRequest represents the CBOR data for log show request
+type LogShowRequest struct {
+	// LogName to show entries of. If empty - entries of all logs are returned.
+	LogName string `cbor:"log_name,omitempty"`
+	// Ts is the timestamp in microseconds, only newer entries are returned.
+	Ts int64 `cbor:"ts,omitempty"`
+	// Index of the first entry to return.
+	Index uint32 `cbor:"index,omitempty"`
+}
+
+// LogShowResponse represents the CBOR data for log show response
+type LogShowResponse struct {
+	// NextIndex is the index of the entry after the last returned one.
+	NextIndex uint32         `cbor:"next_index"`
+	Logs      []Log          `cbor:"logs"`
+	Err       *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// Log represents the CBOR data of a single log in log show response
+type Log struct {
+	Name    string     `cbor:"name"`
+	Type    int        `cbor:"type"`
+	Entries []LogEntry `cbor:"entries"`
+}
+
+// LogEntry represents the CBOR data of a single log entry
+type LogEntry struct {
+	Msg LogMessage `cbor:"msg"`
+	// Ts is the timestamp in microseconds.
+	Ts     int64  `cbor:"ts"`
+	Level  int    `cbor:"level"`
+	Index  uint32 `cbor:"index"`
+	Module int    `cbor:"module"`
+	// Type of the message: LogEntryTypeString, LogEntryTypeCBOR or LogEntryTypeBinary.
+	Type string `cbor:"type,omitempty"`
+}
+
+// Time returns timestamp of the entry.
+func (e LogEntry) Time() time.Time {
+	return time.UnixMicro(e.Ts)
+}
+
+// LogMessage is the message of log entry.
+//
+// Devices send it either as text or as byte string.
+type LogMessage []byte
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (m *LogMessage) UnmarshalCBOR(data []byte) error {
+	var raw any
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	switch v := raw.(type) {
+	case string:
+		*m = LogMessage(v)
+	case []byte:
+		*m = v
+	default:
+		return fmt.Errorf("log message must be text or byte string, got %T", raw)
+	}
+
+	return nil
+}
+
+// LogModuleListResponse represents the CBOR data for log module list response
+type LogModuleListResponse struct {
+	ModuleMap map[string]int `cbor:"module_map"`
+	Err       *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// LogLevelListResponse represents the CBOR data for log level list response
+type LogLevelListResponse struct {
+	LevelMap map[string]int `cbor:"level_map"`
+	Err      *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// LogListResponse represents the CBOR data for log list response
+type LogListResponse struct {
+	LogList []string       `cbor:"log_list"`
+	Err     *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// SettingsReadRequest represents the CBOR data for settings read request
+type SettingsReadRequest struct {
+	Name string `cbor:"name"`
+	// MaxSize of the value to read. If zero - device default is used.
+	MaxSize uint32 `cbor:"max_size,omitempty"`
+}
+
+// SettingsReadResponse represents the CBOR data for settings read response
+type SettingsReadResponse struct {
+	Val []byte `cbor:"val"`
+	// MaxSize is set if device supports smaller values than requested.
+	MaxSize *uint32        `cbor:"max_size,omitempty"`
+	Err     *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// SettingsWriteRequest represents the CBOR data for settings write request
+type SettingsWriteRequest struct {
+	Name string `cbor:"name"`
+	Val  []byte `cbor:"val"`
+}
+
+// SettingsDeleteRequest represents the CBOR data for settings delete request
+type SettingsDeleteRequest struct {
+	Name string `cbor:"name"`
+}
+
+// CrashTriggerRequest represents the CBOR data for crash trigger request
+type CrashTriggerRequest struct {
+	T string `cbor:"t"` // Type of the crash
+}
+
+// TestRunRequest represents the CBOR data for runtime test run request
+type TestRunRequest struct {
+	// Testname of the test to run, "all" runs all tests.
+	Testname string `cbor:"testname"`
+	// Token is an optional string that is printed to the test log.
+	Token string `cbor:"token,omitempty"`
+}
+
+// TestListResponse represents the CBOR data for runtime test list response
+type TestListResponse struct {
+	RunList []string       `cbor:"run_list"`
+	Err     *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// FileDownloadRequest represents the CBOR data for file download request
+type FileDownloadRequest struct {
+	Off  uint64 `cbor:"off"`
+	Name string `cbor:"name"`
+}
+
+// FileDownloadResponse represents the CBOR data for file download response
+type FileDownloadResponse struct {
+	Off  uint64 `cbor:"off"`
+	Data []byte `cbor:"data"`
+	// Len is the total length of the file, sent only with first chunk.
+	Len *uint64        `cbor:"len,omitempty"`
+	Err *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// FileUploadRequest represents the CBOR data for file upload request
+type FileUploadRequest struct {
+	Off  uint64 `cbor:"off"`
+	Data []byte `cbor:"data"`
+	Name string `cbor:"name"`
+	// Len is the total length of the file, sent only with first chunk.
+	Len *uint64 `cbor:"len,omitempty"`
+}
+
+// FileUploadResponse represents the CBOR data for file upload response
+type FileUploadResponse struct {
+	Off uint64         `cbor:"off"`
+	Err *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// FileStatusRequest represents the CBOR data for file status request
+type FileStatusRequest struct {
+	Name string `cbor:"name"`
+}
+
+// FileStatusResponse represents the CBOR data for file status response
+type FileStatusResponse struct {
+	Len uint64         `cbor:"len"`
+	Err *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// FileHashRequest represents the CBOR data for file hash/checksum request
+type FileHashRequest struct {
+	Name string `cbor:"name"`
+	// Type of hash or checksum. If empty - device default is used.
+	Type string `cbor:"type,omitempty"`
+	Off  uint64 `cbor:"off,omitempty"`
+	// Len of data to hash. If zero - data until the end of file is used.
+	Len uint64 `cbor:"len,omitempty"`
+}
+
+// FileHashResponse represents the CBOR data for file hash/checksum response
+type FileHashResponse struct {
+	Type string `cbor:"type"`
+	Off  uint64 `cbor:"off,omitempty"`
+	Len  uint64 `cbor:"len"`
+	// Output is either byte string for hashes, or integer for checksums.
+	Output cbor.RawMessage `cbor:"output"`
+	Err    *ErrorResponse  `cbor:"err,omitempty"` // Optional error response
+}
+
+// FileHashTypesResponse represents the CBOR data for supported hash/checksum types response
+type FileHashTypesResponse struct {
+	Types map[string]FileHashType `cbor:"types"`
+	Err   *ErrorResponse          `cbor:"err,omitempty"` // Optional error response
+}
+
+// FileHashType describes supported hash/checksum type
+type FileHashType struct {
+	// Format of the output: FileHashFormatNumber or FileHashFormatBytes.
+	Format int `cbor:"format"`
+	// Size of the output in bytes.
+	Size int `cbor:"size"`
+}
+
+// ShellExecRequest represents the CBOR data for shell command execution request
+type ShellExecRequest struct {
+	Argv []string `cbor:"argv"`
+}
+
+// ShellExecResponse represents the CBOR data for shell command execution response
+type ShellExecResponse struct {
+	O   string         `cbor:"o"`             // Output of the command
+	Ret int            `cbor:"ret"`           // Return code of the command
+	Err *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// EnumCountResponse represents the CBOR data for enumeration group count response
+type EnumCountResponse struct {
+	Count uint16         `cbor:"count"`
+	Err   *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// EnumListResponse represents the CBOR data for enumeration group list response
+type EnumListResponse struct {
+	Groups []uint16       `cbor:"groups"`
+	Err    *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// EnumSingleRequest represents the CBOR data for enumeration single group request
+type EnumSingleRequest struct {
+	Index uint16 `cbor:"index"`
+}
+
+// EnumSingleResponse represents the CBOR data for enumeration single group response
+type EnumSingleResponse struct {
+	Group uint16 `cbor:"group"`
+	// End is set if this is the last group.
+	End bool           `cbor:"end,omitempty"`
+	Err *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// EnumDetailsRequest represents the CBOR data for enumeration group details request
+type EnumDetailsRequest struct {
+	// Groups to get details of. If empty - all groups are returned.
+	Groups []uint16 `cbor:"groups,omitempty"`
+}
+
+// EnumDetailsResponse represents the CBOR data for enumeration group details response
+type EnumDetailsResponse struct {
+	Groups []GroupDetails `cbor:"groups"`
+	Err    *ErrorResponse `cbor:"err,omitempty"` // Optional error response
+}
+
+// GroupDetails describes group supported by the device
+type GroupDetails struct {
+	Group uint16 `cbor:"group"`
+	// Name of the group, empty if device does not report names.
+	Name string `cbor:"name,omitempty"`
+	// Handlers is the number of commands in the group, zero if device does not report it.
+	Handlers uint16 `cbor:"handlers,omitempty"`
+}
+
 // BuildResetRequest creates a CBOR-encoded reset request
 func BuildResetRequest(force bool) ResetRequest {
 	return ResetRequest{Force: force}
 }
 
+// BuildEchoRequest creates a CBOR-encoded echo request
+func BuildEchoRequest(data string) EchoRequest {
+	return EchoRequest{D: data}
+}
+
+// BuildConsoleEchoRequest creates a CBOR-encoded console echo control request
+func BuildConsoleEchoRequest(enabled bool) ConsoleEchoRequest {
+	if enabled {
+		return ConsoleEchoRequest{Echo: 1}
+	}
+
+	return ConsoleEchoRequest{Echo: 0}
+}
+
+// BuildOSInfoRequest creates a CBOR-encoded OS info request
+func BuildOSInfoRequest(format string) OSInfoRequest {
+	return OSInfoRequest{Format: format}
+}
+
+// BuildBootloaderInfoRequest creates a CBOR-encoded bootloader info request
+func BuildBootloaderInfoRequest(query string) BootloaderInfoRequest {
+	return BootloaderInfoRequest{Query: query}
+}
+
+// BuildDateTimeRequest creates a CBOR-encoded date-time write request
+func BuildDateTimeRequest(dateTime string) DateTimeRequest {
+	return DateTimeRequest{DateTime: dateTime}
+}
+
 // BuildFirmwareUploadRequest creates a CBOR-encoded firmware upload request
 func BuildFirmwareUploadRequest(image uint32, length uint32, offset uint32, sha256 []byte, data []byte, upgrade bool) FirmwareUploadRequest {
 	req := FirmwareUploadRequest{
@@ -99,6 +564,11 @@ func BuildImageStateRequest() ImageStateRequest {
 	return ImageStateRequest{}
 }
 
+// BuildImageStateSetRequest creates a CBOR-encoded image state write request
+func BuildImageStateSetRequest(hash []byte, confirm bool) ImageStateSetRequest {
+	return ImageStateSetRequest{Hash: hash, Confirm: confirm}
+}
+
 // BuildImageEraseRequest creates a CBOR-encoded image erase request
 func BuildImageEraseRequest(slot *uint32) ImageEraseRequest {
 	return ImageEraseRequest{Slot: slot}
@@ -106,13 +576,13 @@ func BuildImageEraseRequest(slot *uint32) ImageEraseRequest {
 
 // FrameToSMPFrame converts a raw frame data to SMPFrame structure
 func FrameToSMPFrame(frameData []byte) (SMPFrame, error) {
-	if len(frameData) < 8 {
-		return SMPFrame{}, fmt.Errorf("frame too small, minimum 8 bytes required")
+	if len(frameData) < SMPHeaderSize {
+		return SMPFrame{}, fmt.Errorf("frame too small, minimum %d bytes required", SMPHeaderSize)
 	}
 
 	// Extract header (first 8 bytes)
-	headerBytes := frameData[:8]
-	dataBytes := frameData[8:]
+	headerBytes := frameData[:SMPHeaderSize]
+	dataBytes := frameData[SMPHeaderSize:]
 
 	// Parse header fields assuming big-endian
 	header := SMPHeader{
@@ -120,7 +590,7 @@ func FrameToSMPFrame(frameData []byte) (SMPFrame, error) {
 		Op:          headerBytes[0] & 0x07,
 		Flags:       headerBytes[1],
 		DataLength:  uint16(headerBytes[2])<<8 | uint16(headerBytes[3]),
-		GroupID:     headerBytes[5],
+		GroupID:     uint16(headerBytes[4])<<8 | uint16(headerBytes[5]),
 		SequenceNum: headerBytes[6],
 		CommandID:   headerBytes[7],
 	}
@@ -139,16 +609,15 @@ func FrameToSMPFrame(frameData []byte) (SMPFrame, error) {
 // SMPFrameToFrame converts SMPFrame to raw frame data
 func SMPFrameToFrame(frame SMPFrame) ([]byte, error) {
 	// Create header buffer
-	header := make([]byte, 8, 8+len(frame.Data))
+	header := make([]byte, SMPHeaderSize, SMPHeaderSize+len(frame.Data))
 
 	// Pack header fields into bytes
 	header[0] = (frame.Header.Version << 3) | frame.Header.Op
 	header[1] = frame.Header.Flags
 	header[2] = byte(frame.Header.DataLength >> 8)
 	header[3] = byte(frame.Header.DataLength & 0xFF)
-	// header[4] is groupID, but it will always be empty
-	// for groups without user-defined ones.
-	header[5] = frame.Header.GroupID
+	header[4] = byte(frame.Header.GroupID >> 8)
+	header[5] = byte(frame.Header.GroupID & 0xFF)
 	header[6] = frame.Header.SequenceNum
 	header[7] = frame.Header.CommandID
 
@@ -0,0 +1,42 @@
+package smp
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFrameCodec(t *testing.T) {
+	t.Parallel()
+
+	frame := SMPFrame{
+		Header: SMPHeader{
+			Version:     SMPVersion2,
+			Op:          SMPOpWriteRequest,
+			Flags:       0x01,
+			DataLength:  3,
+			GroupID:     0x1234,
+			SequenceNum: 0xfe,
+			CommandID:   0x05,
+		},
+		Data: []byte{1, 2, 3},
+	}
+
+	raw, err := SMPFrameToFrame(frame)
+	if err != nil {
+		t.Fatalf("encode frame: %s", err.Error())
+	}
+
+	wantRaw := []byte{0x0a, 0x01, 0x00, 0x03, 0x12, 0x34, 0xfe, 0x05, 1, 2, 3}
+	if !bytes.Equal(raw, wantRaw) {
+		t.Fatalf("wrong encoded frame: %x, want: %x", raw, wantRaw)
+	}
+
+	decoded, err := FrameToSMPFrame(raw)
+	if err != nil {
+		t.Fatalf("decode frame: %s", err.Error())
+	}
+
+	if decoded.Header != frame.Header || !bytes.Equal(decoded.Data, frame.Data) {
+		t.Fatalf("decoded frame differs: %+v, want: %+v", decoded, frame)
+	}
+}